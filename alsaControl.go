@@ -0,0 +1,168 @@
+package sstat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Constants from sound/asound.h.
+const (
+	sndrvCtlElemIfaceMixer = 2
+
+	sndrvCtlElemTypeBoolean = 1
+	sndrvCtlElemTypeInteger = 2
+
+	sndrvCtlIoctlElemInfo = 0x11
+	sndrvCtlIoctlElemRead = 0x12
+)
+
+// sndCtlElemId is struct snd_ctl_elem_id.
+type sndCtlElemId struct {
+	numid     uint32
+	iface     int32
+	device    uint32
+	subdevice uint32
+	name      [44]byte
+	index     uint32
+}
+
+// sndCtlElemInfo is struct snd_ctl_elem_info
+// with the integer member of the value union.
+type sndCtlElemInfo struct {
+	id       sndCtlElemId
+	typ      int32
+	access   uint32
+	count    uint32
+	owner    int32
+	min      int
+	max      int
+	step     int
+	_        [128 - 3*unsafe.Sizeof(int(0))]byte
+	reserved [64]byte
+}
+
+// sndCtlElemValue is struct snd_ctl_elem_value
+// with the integer member of the value union. The union is as large
+// as its 128 longs on every architecture, and is aligned to its long
+// longs, see [sndCtlElemValuePad].
+type sndCtlElemValue struct {
+	id       sndCtlElemId
+	indirect uint32
+	_        [sndCtlElemValuePad]byte
+	values   [128]int
+	reserved [128]byte
+}
+
+// alsaControl reads mixer elements of a sound card. It is implemented by
+// [ioctlControl] on top of the ALSA control device and is an interface
+// so that mixer parsing can be tested without sound hardware.
+type alsaControl interface {
+	// elemInfo reports the type, number of channels and
+	// range of the mixer element name. Missing elements
+	// report an error wrapping [fs.ErrNotExist].
+	elemInfo(name string) (typ, count, min, max int, err error)
+
+	// elemRead reports the value of each channel of the mixer element name.
+	elemRead(name string, count int) ([]int, error)
+
+	close() error
+}
+
+// ioctlControl is an [alsaControl] backed by /dev/snd/controlC*.
+type ioctlControl struct {
+	file *os.File
+}
+
+// ioc encodes an _IOWR('U', nr, size) request number
+// in the _IOC layout of the architecture, see [iocDirShift].
+func ioc(nr, size uintptr) uintptr {
+	return (iocRead|iocWrite)<<iocDirShift | size<<16 | 'U'<<8 | nr
+}
+
+func mixerElemId(name string) (sndCtlElemId, error) {
+	var id sndCtlElemId
+
+	if len(name) >= len(id.name) {
+		return id, fmt.Errorf("%s: mixer element name too long", name)
+	}
+
+	id.iface = sndrvCtlElemIfaceMixer
+	copy(id.name[:], name)
+
+	return id, nil
+}
+
+func (control *ioctlControl) ioctl(req uintptr, arg unsafe.Pointer) error {
+	var errno unix.Errno
+
+	_, _, errno = unix.Syscall(unix.SYS_IOCTL, control.file.Fd(), req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+func (control *ioctlControl) elemInfo(name string) (typ, count, min, max int, err error) {
+	var info sndCtlElemInfo
+
+	info.id, err = mixerElemId(name)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	err = control.ioctl(ioc(sndrvCtlIoctlElemInfo, unsafe.Sizeof(info)), unsafe.Pointer(&info))
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("%s: %s: %w", control.file.Name(), name, err)
+	}
+
+	return int(info.typ), int(info.count), info.min, info.max, nil
+}
+
+func (control *ioctlControl) elemRead(name string, count int) ([]int, error) {
+	var (
+		value *sndCtlElemValue
+		err   error
+	)
+
+	value = new(sndCtlElemValue)
+
+	if count > len(value.values) {
+		return nil, fmt.Errorf("%s: too many channels", name)
+	}
+
+	value.id, err = mixerElemId(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = control.ioctl(ioc(sndrvCtlIoctlElemRead, unsafe.Sizeof(*value)), unsafe.Pointer(value))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", control.file.Name(), name, err)
+	}
+
+	return append([]int(nil), value.values[:count]...), nil
+}
+
+func (control *ioctlControl) close() error {
+	return control.file.Close()
+}
+
+func openControl(card int) (*ioctlControl, error) {
+	var (
+		file *os.File
+		err  error
+	)
+
+	file, err = os.Open(filepath.Join(ALSADevPath, "controlC"+strconv.Itoa(card)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ioctlControl{file: file}, nil
+}
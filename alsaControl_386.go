@@ -0,0 +1,6 @@
+package sstat
+
+// sndCtlElemValuePad is the padding before the value union of struct
+// snd_ctl_elem_value. Long longs are aligned to 4 bytes on 386, so the
+// union directly follows the indirect bit field.
+const sndCtlElemValuePad = 0
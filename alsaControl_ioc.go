@@ -0,0 +1,12 @@
+//go:build !(mips || mipsle || mips64 || mips64le || ppc64 || ppc64le || sparc64)
+
+package sstat
+
+// The _IOC layout of include/uapi/asm-generic/ioctl.h: 14 size bits
+// followed by the direction bits.
+const (
+	iocRead     = 2
+	iocWrite    = 1
+	iocDirShift = 30
+	iocSizeBits = 14
+)
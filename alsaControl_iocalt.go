@@ -0,0 +1,12 @@
+//go:build mips || mipsle || mips64 || mips64le || ppc64 || ppc64le || sparc64
+
+package sstat
+
+// The _IOC layout of the mips, powerpc and sparc ioctl.h: 13 size bits
+// followed by 3 direction bits.
+const (
+	iocRead     = 2
+	iocWrite    = 4
+	iocDirShift = 29
+	iocSizeBits = 13
+)
@@ -0,0 +1,8 @@
+//go:build !386
+
+package sstat
+
+// sndCtlElemValuePad is the padding before the value union of struct
+// snd_ctl_elem_value. Long longs are aligned to 8 bytes, so the union
+// is padded after the indirect bit field.
+const sndCtlElemValuePad = 4
@@ -0,0 +1,39 @@
+package sstat
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestIoctlControlLayout(t *testing.T) {
+	var (
+		elemRead     uintptr
+		valuesOffset uintptr
+	)
+
+	switch {
+	case runtime.GOARCH == "386":
+		elemRead, valuesOffset = 0xc2c45512, 68
+	case unsafe.Sizeof(int(0)) == 4:
+		elemRead, valuesOffset = 0xc2c85512, 72
+	default:
+		elemRead, valuesOffset = 0xc4c85512, 72
+	}
+
+	if ioc(sndrvCtlIoctlElemInfo, unsafe.Sizeof(sndCtlElemInfo{})) != 0xc1105511 {
+		t.Errorf("unexpected SNDRV_CTL_IOCTL_ELEM_INFO %#x", ioc(sndrvCtlIoctlElemInfo, unsafe.Sizeof(sndCtlElemInfo{})))
+	}
+
+	if ioc(sndrvCtlIoctlElemRead, unsafe.Sizeof(sndCtlElemValue{})) != elemRead {
+		t.Errorf("expected SNDRV_CTL_IOCTL_ELEM_READ %#x, got %#x", elemRead, ioc(sndrvCtlIoctlElemRead, unsafe.Sizeof(sndCtlElemValue{})))
+	}
+
+	if unsafe.Sizeof(sndCtlElemValue{}) >= 1<<iocSizeBits {
+		t.Errorf("expected a size below %#x, got %#x", 1<<iocSizeBits, unsafe.Sizeof(sndCtlElemValue{}))
+	}
+
+	if unsafe.Offsetof(sndCtlElemInfo{}.min) != 80 || unsafe.Offsetof(sndCtlElemValue{}.values) != valuesOffset {
+		t.Error("unexpected ALSA control struct layout")
+	}
+}
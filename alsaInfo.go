@@ -0,0 +1,415 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ALSAPath is the directory where the ALSA
+// sound card information is located.
+const ALSAPath string = "/proc/asound"
+
+// ALSADevPath is the directory where the ALSA
+// device nodes are located.
+const ALSADevPath string = "/dev/snd"
+
+// SoundCardInfo reports sound card information from [ALSAPath]/cards.
+type SoundCardInfo struct {
+	index    int
+	id       string
+	driver   string
+	name     string
+	longName string
+}
+
+// Index reports the index of the card, as used by [Mixer].
+func (info *SoundCardInfo) Index() (value int) {
+	return info.index
+}
+
+// Id reports the identifier of the card, e.g. "PCH".
+func (info *SoundCardInfo) Id() (value string) {
+	return info.id
+}
+
+// Driver reports the driver of the card, e.g. "HDA-Intel".
+func (info *SoundCardInfo) Driver() (value string) {
+	return info.driver
+}
+
+// Name reports the short name of the card, e.g. "HDA Intel PCH".
+func (info *SoundCardInfo) Name() (value string) {
+	return info.name
+}
+
+// LongName reports the long name of the card,
+// e.g. "HDA Intel PCH at 0xf7f10000 irq 33".
+func (info *SoundCardInfo) LongName() (value string) {
+	return info.longName
+}
+
+var soundCardRegexp = regexp.MustCompile(`^\s*(\d+)\s+\[(.*?)\s*\]:\s+(.*?)\s+-\s+(.*)$`)
+
+func soundCards(path string) ([]*SoundCardInfo, error) {
+	var (
+		cardInfos []*SoundCardInfo
+		err       error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			matches []string
+			err     error
+		)
+
+		if strings.TrimSpace(text) == "--- no soundcards ---" {
+			return false, nil
+		}
+
+		matches = soundCardRegexp.FindStringSubmatch(text)
+		if matches == nil {
+			if len(cardInfos) == 0 {
+				return false, fmt.Errorf("%s: invalid cards format", path)
+			}
+
+			cardInfos[len(cardInfos)-1].longName = strings.TrimSpace(text)
+
+			return true, nil
+		}
+
+		cardInfos = append(cardInfos, &SoundCardInfo{
+			id:     matches[2],
+			driver: matches[3],
+			name:   matches[4],
+		})
+
+		cardInfos[len(cardInfos)-1].index, err = strconv.Atoi(matches[1])
+
+		return err == nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cardInfos, nil
+}
+
+// SoundCards returns every sound card in [ALSAPath]/cards.
+func SoundCards() ([]*SoundCardInfo, error) {
+	return soundCards(filepath.Join(ALSAPath, "cards"))
+}
+
+// PCMSubstreamInfo reports the status of a PCM substream from
+// [ALSAPath]/card*/pcm*/sub*/status.
+type PCMSubstreamInfo struct {
+	card      int
+	device    int
+	playback  bool
+	subdevice int
+	state     string
+	ownerPid  int
+}
+
+// Card reports the index of the sound card of the substream.
+func (info *PCMSubstreamInfo) Card() (value int) {
+	return info.card
+}
+
+// Device reports the PCM device number of the substream.
+func (info *PCMSubstreamInfo) Device() (value int) {
+	return info.device
+}
+
+// Playback reports whether the substream is a playback stream
+// rather than a capture stream.
+func (info *PCMSubstreamInfo) Playback() bool {
+	return info.playback
+}
+
+// Subdevice reports the subdevice number of the substream.
+func (info *PCMSubstreamInfo) Subdevice() (value int) {
+	return info.subdevice
+}
+
+// State reports the state of the substream.
+//
+// Valid values are:
+//   - "CLOSED"
+//   - "OPEN"
+//   - "SETUP"
+//   - "PREPARED"
+//   - "RUNNING"
+//   - "XRUN"
+//   - "DRAINING"
+//   - "PAUSED"
+//   - "SUSPENDED"
+//   - "DISCONNECTED"
+func (info *PCMSubstreamInfo) State() (value string) {
+	return info.state
+}
+
+// Open reports whether an application has opened the substream.
+func (info *PCMSubstreamInfo) Open() bool {
+	return info.state != "CLOSED"
+}
+
+// Running reports whether audio is streaming through the substream.
+func (info *PCMSubstreamInfo) Running() bool {
+	return info.state == "RUNNING" || info.state == "DRAINING"
+}
+
+// OwnerPid reports the PID of the process that opened the substream.
+// It is 0 for closed substreams.
+func (info *PCMSubstreamInfo) OwnerPid() (value int) {
+	return info.ownerPid
+}
+
+var pcmSubstreamRegexp = regexp.MustCompile(`card(\d+)/pcm(\d+)([pc])/sub(\d+)/status$`)
+
+func pcmSubstream(path string) (*PCMSubstreamInfo, error) {
+	var (
+		substreamInfo *PCMSubstreamInfo
+		matches       []string
+		nums          []int
+		err           error
+	)
+
+	matches = pcmSubstreamRegexp.FindStringSubmatch(filepath.ToSlash(path))
+	if matches == nil {
+		return nil, fmt.Errorf("%s: invalid pcm status path", path)
+	}
+
+	nums, err = parseIntFields([]string{matches[1], matches[2], matches[4]})
+	if err != nil {
+		return nil, err
+	}
+
+	substreamInfo = &PCMSubstreamInfo{
+		card:      nums[0],
+		device:    nums[1],
+		playback:  matches[3] == "p",
+		subdevice: nums[2],
+	}
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			key, value string
+			ok         bool
+			err        error
+		)
+
+		if text == "closed" {
+			substreamInfo.state = "CLOSED"
+
+			return false, nil
+		}
+
+		key, value, ok = strings.Cut(text, ":")
+		if !ok {
+			return true, nil
+		}
+
+		switch strings.TrimSpace(key) {
+		case "state":
+			substreamInfo.state = strings.TrimSpace(value)
+		case "owner_pid":
+			substreamInfo.ownerPid, err = strconv.Atoi(strings.TrimSpace(value))
+		}
+
+		return err == nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if substreamInfo.state == "" {
+		return nil, fmt.Errorf("%s: missing pcm state", path)
+	}
+
+	return substreamInfo, nil
+}
+
+func pcmSubstreams(dir string) ([]*PCMSubstreamInfo, error) {
+	var (
+		statusPaths    []string
+		substreamInfos []*PCMSubstreamInfo
+		idx            int
+		err            error
+	)
+
+	statusPaths, err = filepath.Glob(filepath.Join(dir, "card*", "pcm*", "sub*", "status"))
+	if err != nil {
+		return nil, err
+	}
+
+	substreamInfos = make([]*PCMSubstreamInfo, len(statusPaths))
+
+	for idx = range statusPaths {
+		substreamInfos[idx], err = pcmSubstream(statusPaths[idx])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return substreamInfos, nil
+}
+
+// PCMSubstreams returns the status of every PCM substream of every
+// sound card in [ALSAPath].
+func PCMSubstreams() ([]*PCMSubstreamInfo, error) {
+	return pcmSubstreams(ALSAPath)
+}
+
+// MixerInfo reports the playback volume and mute state of a simple mixer
+// control, such as "Master" or "PCM", read through the ALSA control
+// interface of [ALSADevPath]/controlC*.
+type MixerInfo struct {
+	name      string
+	min, max  int
+	volumes   []int
+	switches  []bool
+	hasSwitch bool
+}
+
+// Name reports the name of the simple mixer control, e.g. "Master".
+func (info *MixerInfo) Name() (value string) {
+	return info.name
+}
+
+// Min reports the minimum raw volume of the control.
+func (info *MixerInfo) Min() (value int) {
+	return info.min
+}
+
+// Max reports the maximum raw volume of the control.
+func (info *MixerInfo) Max() (value int) {
+	return info.max
+}
+
+// Volumes reports the raw volume of each channel of the control.
+func (info *MixerInfo) Volumes() (value []int) {
+	return info.volumes
+}
+
+// Percent reports the average volume of the channels of the
+// control as a percentage of the range of the control.
+func (info *MixerInfo) Percent() (value float64) {
+	var (
+		sum int
+		idx int
+	)
+
+	if len(info.volumes) == 0 || info.max == info.min {
+		return 0
+	}
+
+	for idx = range info.volumes {
+		sum += info.volumes[idx] - info.min
+	}
+
+	return float64(sum) / float64(len(info.volumes)) / float64(info.max-info.min) * 100
+}
+
+// Muted reports whether every channel of the control is muted
+// and whether if the control has a playback switch or not.
+func (info *MixerInfo) Muted() (value bool, ok bool) {
+	var idx int
+
+	if !info.hasSwitch {
+		return false, false
+	}
+
+	for idx = range info.switches {
+		if info.switches[idx] {
+			return false, true
+		}
+	}
+
+	return true, true
+}
+
+func mixer(control alsaControl, name string) (*MixerInfo, error) {
+	var (
+		mixerInfo  *MixerInfo
+		values     []int
+		typ, count int
+		idx        int
+		err        error
+	)
+
+	mixerInfo = &MixerInfo{
+		name: name,
+	}
+
+	typ, count, mixerInfo.min, mixerInfo.max, err = control.elemInfo(name + " Playback Volume")
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != sndrvCtlElemTypeInteger {
+		return nil, fmt.Errorf("%s Playback Volume: not an integer control", name)
+	}
+
+	mixerInfo.volumes, err = control.elemRead(name+" Playback Volume", count)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, count, _, _, err = control.elemInfo(name + " Playback Switch")
+	if errors.Is(err, fs.ErrNotExist) {
+		return mixerInfo, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != sndrvCtlElemTypeBoolean {
+		return nil, fmt.Errorf("%s Playback Switch: not a boolean control", name)
+	}
+
+	values, err = control.elemRead(name+" Playback Switch", count)
+	if err != nil {
+		return nil, err
+	}
+
+	mixerInfo.hasSwitch = true
+	mixerInfo.switches = make([]bool, len(values))
+
+	for idx = range values {
+		mixerInfo.switches[idx] = values[idx] != 0
+	}
+
+	return mixerInfo, nil
+}
+
+// Mixer returns the playback volume and mute state of the simple mixer
+// control name, such as "Master" or "PCM", of the sound card with
+// the index card. It does not require a sound server.
+func Mixer(card int, name string) (*MixerInfo, error) {
+	var (
+		control   *ioctlControl
+		mixerInfo *MixerInfo
+		err       error
+	)
+
+	control, err = openControl(card)
+	if err != nil {
+		return nil, err
+	}
+
+	mixerInfo, err = mixer(control, name)
+	if err != nil {
+		control.close()
+
+		return nil, err
+	}
+
+	return mixerInfo, control.close()
+}
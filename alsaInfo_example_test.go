@@ -0,0 +1,44 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the master volume of the first sound card.
+func ExampleMixer() {
+	var (
+		mixerInfo *sstat.MixerInfo
+		muted     bool
+		err       error
+	)
+
+	mixerInfo, err = sstat.Mixer(0, "Master")
+	if err != nil {
+		panic(err)
+	}
+
+	muted, _ = mixerInfo.Muted()
+	fmt.Printf("Volume: %.0f%% muted: %t\n", mixerInfo.Percent(), muted)
+}
+
+// Print whether any sound card is playing audio.
+func ExamplePCMSubstreams() {
+	var (
+		substreamInfos []*sstat.PCMSubstreamInfo
+		idx            int
+		err            error
+	)
+
+	substreamInfos, err = sstat.PCMSubstreams()
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range substreamInfos {
+		if substreamInfos[idx].Playback() && substreamInfos[idx].Running() {
+			fmt.Printf("card %d is playing audio for pid %d\n", substreamInfos[idx].Card(), substreamInfos[idx].OwnerPid())
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package sstat
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+type fakeControl struct {
+	elems map[string][]int
+	min   int
+	max   int
+	typ   map[string]int
+}
+
+func (control *fakeControl) elemInfo(name string) (typ, count, min, max int, err error) {
+	var (
+		values []int
+		ok     bool
+	)
+
+	values, ok = control.elems[name]
+	if !ok {
+		return 0, 0, 0, 0, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+
+	return control.typ[name], len(values), control.min, control.max, nil
+}
+
+func (control *fakeControl) elemRead(name string, count int) ([]int, error) {
+	return control.elems[name][:count], nil
+}
+
+func (control *fakeControl) close() error {
+	return nil
+}
+
+func TestSoundCards(t *testing.T) {
+	var (
+		root      string
+		cardInfos []*SoundCardInfo
+		err       error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"cards": " 0 [PCH            ]: HDA-Intel - HDA Intel PCH\n                      HDA Intel PCH at 0xf7f10000 irq 33\n 1 [NVidia         ]: HDA-Intel - HDA NVidia\n                      HDA NVidia at 0xf7080000 irq 17\n",
+		"empty": "--- no soundcards ---\n",
+	})
+
+	cardInfos, err = soundCards(filepath.Join(root, "cards"))
+	tErrorIf(t, err)
+
+	if len(cardInfos) != 2 || cardInfos[1].Index() != 1 || cardInfos[1].Id() != "NVidia" || cardInfos[1].Driver() != "HDA-Intel" || cardInfos[0].LongName() != "HDA Intel PCH at 0xf7f10000 irq 33" {
+		t.Errorf("unexpected sound cards %v", cardInfos)
+	}
+
+	cardInfos, err = soundCards(filepath.Join(root, "empty"))
+	tErrorIf(t, err)
+
+	if len(cardInfos) != 0 {
+		t.Errorf("expected no sound cards, got %v", cardInfos)
+	}
+
+	if !checkPath(t, ALSAPath) {
+		return
+	}
+
+	_, err = SoundCards()
+	tErrorIf(t, err)
+}
+
+func TestPCMSubstreams(t *testing.T) {
+	var (
+		root           string
+		substreamInfos []*PCMSubstreamInfo
+		err            error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"card0/pcm0p/sub0/status": "state: RUNNING\nowner_pid   : 1234\ntrigger_time: 4242.000000000\ndelay       : 1024\n-----\nhw_ptr      : 96000\n",
+		"card0/pcm0c/sub0/status": "closed\n",
+	})
+
+	substreamInfos, err = pcmSubstreams(root)
+	tErrorIf(t, err)
+
+	if len(substreamInfos) != 2 {
+		t.Fatalf("expected %d substreams, got %d", 2, len(substreamInfos))
+	}
+
+	if substreamInfos[0].Playback() || substreamInfos[0].Open() {
+		t.Errorf("unexpected capture substream %+v", *substreamInfos[0])
+	}
+
+	if !substreamInfos[1].Playback() || !substreamInfos[1].Running() || substreamInfos[1].OwnerPid() != 1234 {
+		t.Errorf("unexpected playback substream %+v", *substreamInfos[1])
+	}
+}
+
+func TestMixer(t *testing.T) {
+	var (
+		control   *fakeControl
+		mixerInfo *MixerInfo
+		muted, ok bool
+		err       error
+	)
+
+	control = &fakeControl{
+		elems: map[string][]int{
+			"Master Playback Volume": {87, 87},
+			"Master Playback Switch": {0, 0},
+			"PCM Playback Volume":    {0, 174},
+		},
+		typ: map[string]int{
+			"Master Playback Volume": sndrvCtlElemTypeInteger,
+			"Master Playback Switch": sndrvCtlElemTypeBoolean,
+			"PCM Playback Volume":    sndrvCtlElemTypeInteger,
+		},
+		max: 174,
+	}
+
+	mixerInfo, err = mixer(control, "Master")
+	tErrorIf(t, err)
+
+	muted, ok = mixerInfo.Muted()
+	if mixerInfo.Percent() != 50 || !ok || !muted {
+		t.Errorf("expected %g%% muted, got %g%% muted %t", 50.0, mixerInfo.Percent(), muted)
+	}
+
+	mixerInfo, err = mixer(control, "PCM")
+	tErrorIf(t, err)
+
+	_, ok = mixerInfo.Muted()
+	if mixerInfo.Percent() != 50 || ok {
+		t.Errorf("expected %g%% without switch, got %g%%", 50.0, mixerInfo.Percent())
+	}
+
+	_, err = mixer(control, "Headphone")
+	if err == nil {
+		t.Error("expected missing control error")
+	}
+}
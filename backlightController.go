@@ -0,0 +1,197 @@
+package sstat
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DefaultBacklightExponent is the exponent of the perceptual curve
+// used by a new [BacklightController].
+const DefaultBacklightExponent float64 = 2
+
+// BacklightController changes the brightness of a backlight. Unlike the
+// rest of the package it writes to [BacklightPath], which usually requires
+// root, membership of the "video" group or a udev rule.
+type BacklightController struct {
+	path          string
+	maxBrightness int
+	exponent      float64
+}
+
+// Exponent reports the exponent of the perceptual curve used by
+// percentages, where raw = max_brightness * (percent / 100) ^ exponent.
+func (controller *BacklightController) Exponent() (value float64) {
+	return controller.exponent
+}
+
+// SetExponent sets the exponent of the perceptual curve.
+// An exponent of 1 makes percentages linear. Higher exponents
+// give more granularity at low brightness. It returns an error
+// if exponent is not a positive finite number.
+func (controller *BacklightController) SetExponent(exponent float64) error {
+	if !(exponent > 0) || math.IsInf(exponent, 1) {
+		return fmt.Errorf("%g: non-positive or infinite exponent", exponent)
+	}
+
+	controller.exponent = exponent
+
+	return nil
+}
+
+// MaxBrightness reports the maximum raw brightness of the backlight.
+func (controller *BacklightController) MaxBrightness() (value int) {
+	return controller.maxBrightness
+}
+
+// Brightness reports the current raw brightness of the backlight.
+func (controller *BacklightController) Brightness() (int, error) {
+	return PathReadInt(filepath.Join(controller.path, "brightness"))
+}
+
+// Percent reports the current brightness of the backlight
+// as a percentage on the perceptual curve.
+func (controller *BacklightController) Percent() (float64, error) {
+	var (
+		brightness int
+		err        error
+	)
+
+	brightness, err = controller.Brightness()
+	if err != nil {
+		return 0, err
+	}
+
+	return controller.toPercent(brightness), nil
+}
+
+func (controller *BacklightController) toPercent(brightness int) float64 {
+	if controller.maxBrightness == 0 {
+		return 0
+	}
+
+	return math.Pow(float64(brightness)/float64(controller.maxBrightness), 1/controller.exponent) * 100
+}
+
+func (controller *BacklightController) toRaw(percent float64) int {
+	percent = math.Max(0, math.Min(100, percent))
+
+	return int(math.Round(math.Pow(percent/100, controller.exponent) * float64(controller.maxBrightness)))
+}
+
+// SetBrightness sets the raw brightness of the backlight, clamped
+// between 0 and [BacklightController.MaxBrightness], and reports
+// the written value. Errors caused by missing write access to the
+// backlight wrap [fs.ErrPermission].
+func (controller *BacklightController) SetBrightness(brightness int) (int, error) {
+	var (
+		file *os.File
+		path string
+		err  error
+	)
+
+	brightness = max(0, min(controller.maxBrightness, brightness))
+	path = filepath.Join(controller.path, "brightness")
+
+	file, err = os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if errors.Is(err, fs.ErrPermission) {
+		return 0, fmt.Errorf("%s: no write access, run as root, join the group owning the file or add a udev rule: %w", path, err)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = file.WriteString(strconv.Itoa(brightness) + "\n")
+	if err != nil {
+		file.Close()
+
+		return 0, err
+	}
+
+	return brightness, file.Close()
+}
+
+// SetPercent sets the brightness of the backlight to a percentage
+// on the perceptual curve, clamped between 0 and 100, and reports
+// the written raw value.
+func (controller *BacklightController) SetPercent(percent float64) (int, error) {
+	return controller.SetBrightness(controller.toRaw(percent))
+}
+
+// StepBrightness changes the raw brightness of the backlight by
+// delta and reports the written raw value.
+func (controller *BacklightController) StepBrightness(delta int) (int, error) {
+	var (
+		brightness int
+		err        error
+	)
+
+	brightness, err = controller.Brightness()
+	if err != nil {
+		return 0, err
+	}
+
+	return controller.SetBrightness(brightness + delta)
+}
+
+// StepPercent changes the brightness of the backlight by delta
+// percent on the perceptual curve and reports the written raw value.
+// A nonzero delta always changes the raw brightness by at least 1
+// unless the brightness is already at its limit.
+func (controller *BacklightController) StepPercent(delta float64) (int, error) {
+	var (
+		brightness, newBrightness int
+		err                       error
+	)
+
+	brightness, err = controller.Brightness()
+	if err != nil {
+		return 0, err
+	}
+
+	newBrightness = controller.toRaw(controller.toPercent(brightness) + delta)
+
+	switch {
+	case delta > 0 && newBrightness <= brightness:
+		newBrightness = brightness + 1
+	case delta < 0 && newBrightness >= brightness:
+		newBrightness = brightness - 1
+	}
+
+	return controller.SetBrightness(newBrightness)
+}
+
+func newBacklightController(dir, basepath string) (*BacklightController, error) {
+	var (
+		controller *BacklightController
+		err        error
+	)
+
+	controller = &BacklightController{
+		path:     filepath.Join(dir, basepath),
+		exponent: DefaultBacklightExponent,
+	}
+
+	controller.maxBrightness, err = PathReadInt(filepath.Join(controller.path, "max_brightness"))
+	if err != nil {
+		return nil, err
+	}
+
+	return controller, nil
+}
+
+// NewBacklightController returns a controller for the
+// backlight in [BacklightPath] + basepath.
+func NewBacklightController(basepath string) (*BacklightController, error) {
+	return newBacklightController(BacklightPath, basepath)
+}
+
+// Controller returns a controller for the backlight.
+func (info *BacklightInfo) Controller() (*BacklightController, error) {
+	return NewBacklightController(info.name)
+}
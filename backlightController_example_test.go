@@ -0,0 +1,33 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Increase the brightness of intel_backlight by 5%.
+func ExampleBacklightController_StepPercent() {
+	var (
+		controller *sstat.BacklightController
+		perc       float64
+		err        error
+	)
+
+	controller, err = sstat.NewBacklightController("intel_backlight")
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = controller.StepPercent(5)
+	if err != nil {
+		panic(err)
+	}
+
+	perc, err = controller.Percent()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Brightness: %.0f%%\n", perc)
+}
@@ -0,0 +1,144 @@
+package sstat
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func backlightTree(t *testing.T) string {
+	return tmpTree(t, map[string]string{
+		"intel_backlight/brightness":        "9600\n",
+		"intel_backlight/actual_brightness": "9600\n",
+		"intel_backlight/max_brightness":    "19200\n",
+		"intel_backlight/bl_power":          "0\n",
+		"intel_backlight/type":              "raw\n",
+	})
+}
+
+func TestBacklightController(t *testing.T) {
+	var (
+		tests = []struct {
+			name string
+			set  func(controller *BacklightController) (int, error)
+			want int
+		}{
+			{"raw", func(c *BacklightController) (int, error) { return c.SetBrightness(100) }, 100},
+			{"raw clamp high", func(c *BacklightController) (int, error) { return c.SetBrightness(50000) }, 19200},
+			{"raw clamp low", func(c *BacklightController) (int, error) { return c.SetBrightness(-5) }, 0},
+			{"percent", func(c *BacklightController) (int, error) { return c.SetPercent(50) }, 4800},
+			{"percent full", func(c *BacklightController) (int, error) { return c.SetPercent(150) }, 19200},
+			{"step raw", func(c *BacklightController) (int, error) { return c.StepBrightness(-600) }, 9000},
+			{"step percent", func(c *BacklightController) (int, error) { return c.StepPercent(-10) }, 7077},
+		}
+		root       string
+		controller *BacklightController
+		value      int
+		idx        int
+		err        error
+	)
+
+	for idx = range tests {
+		root = backlightTree(t)
+
+		controller, err = newBacklightController(root, "intel_backlight")
+		tErrorIf(t, err)
+
+		value, err = tests[idx].set(controller)
+		tErrorIf(t, err)
+
+		if value != tests[idx].want {
+			t.Errorf("%s: expected %d, got %d", tests[idx].name, tests[idx].want, value)
+		}
+
+		value, err = controller.Brightness()
+		tErrorIf(t, err)
+
+		if value != tests[idx].want {
+			t.Errorf("%s: expected %d written, got %d", tests[idx].name, tests[idx].want, value)
+		}
+	}
+}
+
+func TestBacklightControllerStepLow(t *testing.T) {
+	var (
+		root       string
+		controller *BacklightController
+		value      int
+		err        error
+	)
+
+	root = backlightTree(t)
+
+	controller, err = newBacklightController(root, "intel_backlight")
+	tErrorIf(t, err)
+
+	_, err = controller.SetBrightness(0)
+	tErrorIf(t, err)
+
+	value, err = controller.StepPercent(0.01)
+	tErrorIf(t, err)
+
+	if value != 1 {
+		t.Errorf("expected %d, got %d", 1, value)
+	}
+}
+
+func TestBacklightControllerSetExponent(t *testing.T) {
+	var (
+		controller *BacklightController
+		exponent   float64
+		value      int
+		err        error
+	)
+
+	controller, err = newBacklightController(backlightTree(t), "intel_backlight")
+	tErrorIf(t, err)
+
+	for _, exponent = range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		err = controller.SetExponent(exponent)
+		if err == nil {
+			t.Errorf("expected error for exponent %g", exponent)
+		}
+	}
+
+	if controller.Exponent() != DefaultBacklightExponent {
+		t.Errorf("expected %g, got %g", DefaultBacklightExponent, controller.Exponent())
+	}
+
+	err = controller.SetExponent(1)
+	tErrorIf(t, err)
+
+	value, err = controller.SetPercent(50)
+	tErrorIf(t, err)
+
+	if value != 9600 {
+		t.Errorf("expected %d, got %d", 9600, value)
+	}
+}
+
+func TestBacklightControllerPermission(t *testing.T) {
+	var (
+		root       string
+		controller *BacklightController
+		err        error
+	)
+
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores file permissions")
+	}
+
+	root = backlightTree(t)
+	tErrorIf(t, os.Chmod(filepath.Join(root, "intel_backlight", "brightness"), 0o444))
+
+	controller, err = newBacklightController(root, "intel_backlight")
+	tErrorIf(t, err)
+
+	_, err = controller.SetPercent(50)
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected %v, got %v", fs.ErrPermission, err)
+	}
+}
@@ -1,7 +1,13 @@
 package sstat
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
 )
@@ -20,6 +26,7 @@ type BacklightInfo struct {
 	actualBrightness int
 	maxBrightness    int
 	typ, name        string
+	connector        string
 }
 
 // BlPower reports BACKLIGHT power, values are
@@ -75,6 +82,26 @@ func (info *BacklightInfo) Name() (value string) {
 	return info.name
 }
 
+// Connector reports the DRM connector of the panel controlled by the
+// backlight, e.g. "card0-eDP-1", and whether if the connector
+// could be determined or not. Firmware and platform backlights are
+// not linked to a connector by the kernel, so they report the internal
+// panel if [DRMPath] has exactly one.
+func (info *BacklightInfo) Connector() (value string, ok bool) {
+	return info.connector, info.connector != ""
+}
+
+// Display reports the display controlled by the backlight as named by
+// display servers, e.g. "eDP-1", and whether if the display could be
+// determined or not.
+func (info *BacklightInfo) Display() (value string, ok bool) {
+	var found bool
+
+	_, value, found = strings.Cut(info.connector, "-")
+
+	return value, found
+}
+
 func (info *BacklightInfo) mapIntPtrs() map[string]*int {
 	return map[string]*int{
 		"bl_power":          &info.blPower,
@@ -84,54 +111,57 @@ func (info *BacklightInfo) mapIntPtrs() map[string]*int {
 	}
 }
 
-func serveBacklight(backlightChans map[string]<-chan *BacklightInfo, errChan chan<- error, basepath string) {
+func serveBacklight(ctx context.Context, backlightChan chan<- *BacklightInfo, errChan chan<- error, dir, basepath string) {
 	var (
 		backlightInfo, newBacklightInfo *BacklightInfo
-		backlightChan                   chan *BacklightInfo
 		watcher                         *fsnotify.Watcher
 		event                           fsnotify.Event
 		infoPath, infoName              string
 		err                             error
 	)
 
-	backlightInfo, err = Backlight(basepath)
+	backlightInfo, err = backlight(dir, basepath)
 	if err != nil {
-		errChan <- err
+		send(ctx, errChan, err)
 
 		return
 	}
 
 	watcher, err = fsnotify.NewWatcher()
 	if err != nil {
-		errChan <- err
+		send(ctx, errChan, err)
 
 		return
 	}
 
+	defer watcher.Close()
+
 	for _, infoPath = range []string{"bl_power", "brightness", "actual_brightness", "max_brightness", "type"} {
-		err = watcher.Add(filepath.Join(BacklightPath, basepath, infoPath))
+		err = watcher.Add(filepath.Join(dir, basepath, infoPath))
 		if err != nil {
-			errChan <- err
+			send(ctx, errChan, err)
 
 			return
 		}
 	}
 
-	backlightChan = make(chan *BacklightInfo)
-	backlightChans[basepath] = backlightChan
-	backlightChan <- backlightInfo
+	if !send(ctx, backlightChan, backlightInfo) {
+		return
+	}
 
 	for {
 		newBacklightInfo = new(BacklightInfo)
 		*newBacklightInfo = *backlightInfo
 
 		select {
+		case <-ctx.Done():
+			return
 		case event = <-watcher.Events:
 			if !event.Has(fsnotify.Write) {
 				continue
 			}
 		case err = <-watcher.Errors:
-			errChan <- err
+			send(ctx, errChan, err)
 
 			return
 		}
@@ -140,28 +170,29 @@ func serveBacklight(backlightChans map[string]<-chan *BacklightInfo, errChan cha
 
 		switch infoName {
 		case "type":
-			newBacklightInfo.typ, err = PathReadStr(filepath.Join(BacklightPath, basepath, "type"))
+			newBacklightInfo.typ, err = PathReadStr(filepath.Join(dir, basepath, "type"))
 		default:
-			*newBacklightInfo.mapIntPtrs()[infoName], err = PathReadInt(filepath.Join(BacklightPath, basepath, infoName))
+			*newBacklightInfo.mapIntPtrs()[infoName], err = PathReadInt(filepath.Join(dir, basepath, infoName))
 		}
 
 		if err != nil {
-			errChan <- err
+			send(ctx, errChan, err)
 
 			return
 		}
 
-		backlightChan <- newBacklightInfo
+		if !send(ctx, backlightChan, newBacklightInfo) {
+			return
+		}
+
 		backlightInfo = newBacklightInfo
 	}
 }
 
-// BacklightChans returns a map of channels that sends
-// backlight information for each backlight found in
-// [BacklightPath] + glob.
-func BacklightChans(glob string) (map[string]<-chan *BacklightInfo, <-chan error, error) {
+func backlightChans(dir, glob string) (map[string]<-chan *BacklightInfo, <-chan error, error) {
 	var (
 		backlightChans map[string]<-chan *BacklightInfo
+		backlightChan  chan *BacklightInfo
 		errChan        chan error
 		backlightPaths []string
 		path           string
@@ -171,21 +202,155 @@ func BacklightChans(glob string) (map[string]<-chan *BacklightInfo, <-chan error
 	backlightChans = make(map[string]<-chan *BacklightInfo)
 	errChan = make(chan error)
 
-	backlightPaths, err = filepath.Glob(filepath.Join(BacklightPath, glob))
+	backlightPaths, err = filepath.Glob(filepath.Join(dir, glob))
 	if err != nil {
 		return nil, nil, err
 	}
 
 	for _, path = range backlightPaths {
-		go serveBacklight(backlightChans, errChan, filepath.Base(path))
+		backlightChan = make(chan *BacklightInfo)
+		backlightChans[filepath.Base(path)] = backlightChan
+
+		go serveBacklight(context.Background(), backlightChan, errChan, dir, filepath.Base(path))
 	}
 
 	return backlightChans, errChan, nil
 }
 
-// Backlight returns backlight information in
-// [BacklightPath] + basepath.
-func Backlight(basepath string) (*BacklightInfo, error) {
+// BacklightChans returns a map of channels that sends
+// backlight information for each backlight found in
+// [BacklightPath] + glob. Every channel has a single consumer
+// which must keep up with the changes, see [BacklightBroadcasters]
+// for several consumers.
+func BacklightChans(glob string) (map[string]<-chan *BacklightInfo, <-chan error, error) {
+	return backlightChans(BacklightPath, glob)
+}
+
+func backlightBroadcaster(dir, basepath string) *Broadcaster[*BacklightInfo] {
+	return watchBroadcaster(func(ctx context.Context, backlightChan chan<- *BacklightInfo, errChan chan<- error) {
+		serveBacklight(ctx, backlightChan, errChan, dir, basepath)
+	})
+}
+
+func backlightBroadcasters(dir, glob string) (map[string]*Broadcaster[*BacklightInfo], error) {
+	var (
+		broadcasters   map[string]*Broadcaster[*BacklightInfo]
+		backlightPaths []string
+		path           string
+		err            error
+	)
+
+	broadcasters = make(map[string]*Broadcaster[*BacklightInfo])
+
+	backlightPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path = range backlightPaths {
+		broadcasters[filepath.Base(path)] = backlightBroadcaster(dir, filepath.Base(path))
+	}
+
+	return broadcasters, nil
+}
+
+// BacklightBroadcasters returns a map of [Broadcaster] that publishes
+// backlight information for each backlight found in [BacklightPath] +
+// glob whenever it changes. Any number of consumers may subscribe to
+// each backlight without ever stalling its watcher. A broadcaster is
+// closed with the error of its watcher, see [Broadcaster.Err]. The
+// watcher runs until [Broadcaster.Stop].
+func BacklightBroadcasters(glob string) (map[string]*Broadcaster[*BacklightInfo], error) {
+	return backlightBroadcasters(BacklightPath, glob)
+}
+
+var (
+	drmConnectorRegexp = regexp.MustCompile(`^card[0-9]+-.+$`)
+	panelGlobs         = []string{"card*-eDP-*", "card*-LVDS-*", "card*-DSI-*"}
+)
+
+// panelConnectors reports the internal panel connectors in the DRM
+// directory dir, such as "card0-eDP-1".
+func panelConnectors(dir string) ([]string, error) {
+	var (
+		panels     []string
+		panelPaths []string
+		panelGlob  string
+		idx        int
+		err        error
+	)
+
+	for _, panelGlob = range panelGlobs {
+		panelPaths, err = filepath.Glob(filepath.Join(dir, panelGlob))
+		if err != nil {
+			return nil, err
+		}
+
+		for idx = range panelPaths {
+			panels = append(panels, filepath.Base(panelPaths[idx]))
+		}
+	}
+
+	return panels, nil
+}
+
+// backlightConnector resolves the DRM connector of the backlight
+// in path. The device symlink of raw backlights usually points to the
+// connector itself. Otherwise it may point to the GPU, in which case
+// the only internal panel connector of the GPU is used. Firmware and
+// platform backlights point to neither, so they are assigned the only
+// internal panel connector in the DRM directory drmDir.
+func backlightConnector(path, typ, drmDir string) (string, error) {
+	var (
+		devicePath     string
+		connectorPaths []string
+		matches        []string
+		panels         []string
+		glob           string
+		err            error
+	)
+
+	devicePath, err = filepath.EvalSymlinks(filepath.Join(path, "device"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if err == nil && drmConnectorRegexp.MatchString(filepath.Base(devicePath)) {
+		return filepath.Base(devicePath), nil
+	}
+
+	if err == nil {
+		for _, glob = range panelGlobs {
+			matches, err = filepath.Glob(filepath.Join(devicePath, "drm", "card*", glob))
+			if err != nil {
+				return "", err
+			}
+
+			connectorPaths = append(connectorPaths, matches...)
+		}
+
+		if len(connectorPaths) == 1 {
+			return filepath.Base(connectorPaths[0]), nil
+		}
+	}
+
+	if typ != "firmware" && typ != "platform" {
+		return "", nil
+	}
+
+	panels, err = panelConnectors(drmDir)
+	if err != nil {
+		return "", err
+	}
+
+	if len(panels) != 1 {
+		return "", nil
+	}
+
+	return panels[0], nil
+}
+
+func backlight(dir, basepath string) (*BacklightInfo, error) {
 	var (
 		backlightInfo *BacklightInfo
 		key           string
@@ -198,13 +363,18 @@ func Backlight(basepath string) (*BacklightInfo, error) {
 	}
 
 	for key, value = range backlightInfo.mapIntPtrs() {
-		*value, err = PathReadInt(filepath.Join(BacklightPath, basepath, key))
+		*value, err = PathReadInt(filepath.Join(dir, basepath, key))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	backlightInfo.typ, err = PathReadStr(filepath.Join(BacklightPath, basepath, "type"))
+	backlightInfo.typ, err = PathReadStr(filepath.Join(dir, basepath, "type"))
+	if err != nil {
+		return nil, err
+	}
+
+	backlightInfo.connector, err = backlightConnector(filepath.Join(dir, basepath), backlightInfo.typ, filepath.Join(filepath.Dir(dir), "drm"))
 	if err != nil {
 		return nil, err
 	}
@@ -212,9 +382,13 @@ func Backlight(basepath string) (*BacklightInfo, error) {
 	return backlightInfo, err
 }
 
-// Backlights returns all backlight information in
-// [BacklightPath] + glob.
-func Backlights(glob string) ([]*BacklightInfo, error) {
+// Backlight returns backlight information in
+// [BacklightPath] + basepath.
+func Backlight(basepath string) (*BacklightInfo, error) {
+	return backlight(BacklightPath, basepath)
+}
+
+func backlights(dir, glob string) ([]*BacklightInfo, error) {
 	var (
 		backlightPaths []string
 		backlightInfos []*BacklightInfo
@@ -222,7 +396,7 @@ func Backlights(glob string) ([]*BacklightInfo, error) {
 		err            error
 	)
 
-	backlightPaths, err = filepath.Glob(filepath.Join(BacklightPath, glob))
+	backlightPaths, err = filepath.Glob(filepath.Join(dir, glob))
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +404,7 @@ func Backlights(glob string) ([]*BacklightInfo, error) {
 	backlightInfos = make([]*BacklightInfo, len(backlightPaths))
 
 	for idx = range backlightPaths {
-		backlightInfos[idx], err = Backlight(filepath.Base(backlightPaths[idx]))
+		backlightInfos[idx], err = backlight(dir, filepath.Base(backlightPaths[idx]))
 		if err != nil {
 			return nil, err
 		}
@@ -238,3 +412,134 @@ func Backlights(glob string) ([]*BacklightInfo, error) {
 
 	return backlightInfos, nil
 }
+
+// Backlights returns all backlight information in
+// [BacklightPath] + glob.
+func Backlights(glob string) ([]*BacklightInfo, error) {
+	return backlights(BacklightPath, glob)
+}
+
+var backlightTypeRanks = map[string]int{
+	"firmware": 0,
+	"platform": 1,
+	"raw":      2,
+}
+
+func backlightRank(info *BacklightInfo) int {
+	var (
+		rank int
+		ok   bool
+	)
+
+	rank, ok = backlightTypeRanks[info.typ]
+	if !ok {
+		return len(backlightTypeRanks)
+	}
+
+	return rank
+}
+
+// PreferredBacklight reports the backlight that should be controlled
+// out of backlightInfos and whether if backlightInfos has a backlight
+// or not. As documented in [BacklightInfo.Type], firmware backlights are
+// preferred to platform backlights which are preferred to raw backlights.
+// Ties are broken by name.
+func PreferredBacklight(backlightInfos []*BacklightInfo) (value *BacklightInfo, ok bool) {
+	if len(backlightInfos) == 0 {
+		return nil, false
+	}
+
+	return slices.MinFunc(backlightInfos, func(a, b *BacklightInfo) int {
+		if backlightRank(a) != backlightRank(b) {
+			return backlightRank(a) - backlightRank(b)
+		}
+
+		return strings.Compare(a.name, b.name)
+	}), true
+}
+
+// isPanelConnector reports whether if the DRM connector
+// is an internal panel, e.g. "card0-eDP-1".
+func isPanelConnector(connector string) bool {
+	var (
+		glob    string
+		matched bool
+	)
+
+	for _, glob = range panelGlobs {
+		matched, _ = filepath.Match(glob, connector)
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backlightsByConnector groups backlightInfos by connector, grouping
+// firmware and platform backlights without a connector under the
+// internal panel if panels has exactly one.
+func backlightsByConnector(backlightInfos []*BacklightInfo, panels []string) map[string][]*BacklightInfo {
+	var (
+		groups    map[string][]*BacklightInfo
+		connector string
+		idx       int
+	)
+
+	groups = make(map[string][]*BacklightInfo)
+
+	for idx = range backlightInfos {
+		connector = backlightInfos[idx].connector
+		if connector == "" && len(panels) == 1 && backlightRank(backlightInfos[idx]) < backlightTypeRanks["raw"] {
+			connector = panels[0]
+		}
+
+		groups[connector] = append(groups[connector], backlightInfos[idx])
+	}
+
+	return groups
+}
+
+// BacklightsByConnector groups backlightInfos by [BacklightInfo.Connector].
+// Firmware and platform backlights without a connector are grouped under
+// the internal panel, such as "card0-eDP-1", if the other backlights
+// control exactly one. The remaining backlights
+// without a connector are grouped under "".
+func BacklightsByConnector(backlightInfos []*BacklightInfo) map[string][]*BacklightInfo {
+	var (
+		panels []string
+		idx    int
+	)
+
+	for idx = range backlightInfos {
+		if isPanelConnector(backlightInfos[idx].connector) && !slices.Contains(panels, backlightInfos[idx].connector) {
+			panels = append(panels, backlightInfos[idx].connector)
+		}
+	}
+
+	return backlightsByConnector(backlightInfos, panels)
+}
+
+// preferredBacklights reports the [PreferredBacklight] of each group of
+// groups.
+func preferredBacklights(groups map[string][]*BacklightInfo) map[string]*BacklightInfo {
+	var (
+		preferred map[string]*BacklightInfo
+		connector string
+		group     []*BacklightInfo
+	)
+
+	preferred = make(map[string]*BacklightInfo)
+
+	for connector, group = range groups {
+		preferred[connector], _ = PreferredBacklight(group)
+	}
+
+	return preferred
+}
+
+// PreferredBacklights reports the [PreferredBacklight] of each group
+// in [BacklightsByConnector].
+func PreferredBacklights(backlightInfos []*BacklightInfo) map[string]*BacklightInfo {
+	return preferredBacklights(BacklightsByConnector(backlightInfos))
+}
@@ -6,6 +6,32 @@ import (
 	"github.com/andrieee44/sstat"
 )
 
+// Print the brightness percentage of the preferred backlight.
+func ExamplePreferredBacklight() {
+	var (
+		backlightInfos []*sstat.BacklightInfo
+		backlightInfo  *sstat.BacklightInfo
+		display        string
+		perc           float64
+		ok             bool
+		err            error
+	)
+
+	backlightInfos, err = sstat.Backlights("*")
+	if err != nil {
+		panic(err)
+	}
+
+	backlightInfo, ok = sstat.PreferredBacklight(backlightInfos)
+	if !ok {
+		panic("no backlight found")
+	}
+
+	display, _ = backlightInfo.Display()
+	perc = float64(backlightInfo.Brightness()) / float64(backlightInfo.MaxBrightness()) * 100
+	fmt.Printf("%s Brightness: %g%%\n", display, perc)
+}
+
 // Print the brightness percentage of intel_backlight.
 func ExampleBacklight() {
 	var (
@@ -1,8 +1,10 @@
 package sstat
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestBacklight(t *testing.T) {
@@ -26,3 +28,197 @@ func TestBacklights(t *testing.T) {
 	_, err = Backlights("*")
 	tErrorIf(t, err)
 }
+
+func TestPreferredBacklight(t *testing.T) {
+	var (
+		root           string
+		backlightInfos []*BacklightInfo
+		backlightInfo  *BacklightInfo
+		preferred      map[string]*BacklightInfo
+		display        string
+		name, typ      string
+		path, content  string
+		ok             bool
+		err            error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"devices/pci0000:00/0000:00:02.0/drm/card0/card0-eDP-1/status":    "connected\n",
+		"devices/pci0000:00/0000:00:02.0/drm/card0/card0-HDMI-A-1/status": "disconnected\n",
+		"devices/LNXSYSTM:00/LNXVIDEO:00/uevent":                          "\n",
+		"drm/card0-HDMI-A-1/status":                                       "disconnected\n",
+	})
+
+	for name, typ = range map[string]string{"intel_backlight": "raw", "acpi_video0": "firmware", "amdgpu_bl0": "raw"} {
+		for path, content = range map[string]string{"brightness": "1\n", "actual_brightness": "1\n", "max_brightness": "2\n", "bl_power": "0\n", "type": typ + "\n"} {
+			tErrorIf(t, os.MkdirAll(filepath.Join(root, "class", name), 0o755))
+			tErrorIf(t, os.WriteFile(filepath.Join(root, "class", name, path), []byte(content), 0o644))
+		}
+	}
+
+	tErrorIf(t, os.Symlink(filepath.Join(root, "devices/pci0000:00/0000:00:02.0/drm/card0/card0-eDP-1"), filepath.Join(root, "class/intel_backlight/device")))
+	tErrorIf(t, os.Symlink(filepath.Join(root, "devices/pci0000:00/0000:00:02.0"), filepath.Join(root, "class/amdgpu_bl0/device")))
+	tErrorIf(t, os.Symlink(filepath.Join(root, "devices/LNXSYSTM:00/LNXVIDEO:00"), filepath.Join(root, "class/acpi_video0/device")))
+
+	for _, name = range []string{"intel_backlight", "acpi_video0", "amdgpu_bl0"} {
+		backlightInfo, err = backlight(filepath.Join(root, "class"), name)
+		tErrorIf(t, err)
+
+		backlightInfos = append(backlightInfos, backlightInfo)
+	}
+
+	display, ok = backlightInfos[0].Display()
+	if !ok || display != "eDP-1" {
+		t.Errorf("expected %q, got %q", "eDP-1", display)
+	}
+
+	_, ok = backlightInfos[1].Connector()
+	if ok {
+		t.Errorf("expected %s to have no connector without a panel", backlightInfos[1].Name())
+	}
+
+	display, _ = backlightInfos[2].Display()
+	if display != "eDP-1" {
+		t.Errorf("expected %q, got %q", "eDP-1", display)
+	}
+
+	backlightInfo, ok = PreferredBacklight(backlightInfos)
+	if !ok || backlightInfo.Name() != "acpi_video0" {
+		t.Errorf("expected %q to be preferred, got %v", "acpi_video0", backlightInfo)
+	}
+
+	preferred = PreferredBacklights(backlightInfos)
+	if len(preferred) != 1 || preferred["card0-eDP-1"].Name() != "acpi_video0" {
+		t.Errorf("unexpected preferred backlights %v", preferred)
+	}
+
+	tErrorIf(t, os.Symlink(filepath.Join(root, "devices/pci0000:00/0000:00:02.0/drm/card0/card0-eDP-1"), filepath.Join(root, "drm/card0-eDP-1")))
+
+	backlightInfo, err = backlight(filepath.Join(root, "class"), "acpi_video0")
+	tErrorIf(t, err)
+
+	display, ok = backlightInfo.Display()
+	if !ok || display != "eDP-1" {
+		t.Errorf("expected %q, got %q", "eDP-1", display)
+	}
+
+	_, ok = PreferredBacklight(nil)
+	if ok {
+		t.Error("expected no preferred backlight")
+	}
+}
+
+func TestBacklightsByConnector(t *testing.T) {
+	var (
+		tests = []struct {
+			name           string
+			backlightInfos []*BacklightInfo
+			expected       map[string]string
+		}{
+			{"firmware with one panel", []*BacklightInfo{
+				{name: "acpi_video0", typ: "firmware"},
+				{name: "intel_backlight", typ: "raw", connector: "card0-eDP-1"},
+			}, map[string]string{"card0-eDP-1": "acpi_video0"}},
+			{"platform with one panel", []*BacklightInfo{
+				{name: "dell_backlight", typ: "platform"},
+				{name: "amdgpu_bl0", typ: "raw", connector: "card1-eDP-1"},
+				{name: "ddcci2", typ: "raw", connector: "card1-DP-2"},
+			}, map[string]string{"card1-eDP-1": "dell_backlight", "card1-DP-2": "ddcci2"}},
+			{"firmware with two panels", []*BacklightInfo{
+				{name: "acpi_video0", typ: "firmware"},
+				{name: "intel_backlight", typ: "raw", connector: "card0-eDP-1"},
+				{name: "amdgpu_bl1", typ: "raw", connector: "card1-eDP-2"},
+			}, map[string]string{"": "acpi_video0", "card0-eDP-1": "intel_backlight", "card1-eDP-2": "amdgpu_bl1"}},
+			{"unlinked raw", []*BacklightInfo{
+				{name: "nv_backlight", typ: "raw"},
+				{name: "intel_backlight", typ: "raw", connector: "card0-eDP-1"},
+			}, map[string]string{"": "nv_backlight", "card0-eDP-1": "intel_backlight"}},
+			{"firmware only", []*BacklightInfo{
+				{name: "acpi_video0", typ: "firmware"},
+			}, map[string]string{"": "acpi_video0"}},
+		}
+		preferred       map[string]*BacklightInfo
+		connector, name string
+		idx             int
+	)
+
+	for idx = range tests {
+		preferred = PreferredBacklights(tests[idx].backlightInfos)
+		if len(preferred) != len(tests[idx].expected) {
+			t.Errorf("%s: expected %d connectors, got %v", tests[idx].name, len(tests[idx].expected), preferred)
+
+			continue
+		}
+
+		for connector, name = range tests[idx].expected {
+			if preferred[connector] == nil || preferred[connector].Name() != name {
+				t.Errorf("%s: expected %q for %q, got %v", tests[idx].name, name, connector, preferred[connector])
+			}
+		}
+	}
+}
+
+func TestBacklightBroadcasters(t *testing.T) {
+	var (
+		root          string
+		broadcasters  map[string]*Broadcaster[*BacklightInfo]
+		first, other  <-chan *BacklightInfo
+		backlightInfo *BacklightInfo
+		valueChan     <-chan *BacklightInfo
+		file          *os.File
+		ok            bool
+		err           error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"intel_backlight/brightness":        "100\n",
+		"intel_backlight/actual_brightness": "100\n",
+		"intel_backlight/max_brightness":    "1000\n",
+		"intel_backlight/bl_power":          "0\n",
+		"intel_backlight/type":              "raw\n",
+	})
+
+	broadcasters, err = backlightBroadcasters(root, "*")
+	tErrorIf(t, err)
+
+	first, _ = broadcasters["intel_backlight"].Subscribe(1)
+	other, _ = broadcasters["intel_backlight"].Subscribe(1)
+
+	for _, valueChan = range []<-chan *BacklightInfo{first, other} {
+		select {
+		case backlightInfo = <-valueChan:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for intel_backlight")
+		}
+
+		if backlightInfo.Brightness() != 100 {
+			t.Errorf("expected %d, got %d", 100, backlightInfo.Brightness())
+		}
+	}
+
+	file, err = os.OpenFile(filepath.Join(root, "intel_backlight", "brightness"), os.O_WRONLY, 0)
+	tErrorIf(t, err)
+
+	_, err = file.WriteString("500\n")
+	tErrorIf(t, err)
+	tErrorIf(t, file.Close())
+
+	for _, valueChan = range []<-chan *BacklightInfo{first, other} {
+		select {
+		case backlightInfo = <-valueChan:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for intel_backlight change")
+		}
+
+		if backlightInfo.Brightness() != 500 {
+			t.Errorf("expected %d, got %d", 500, backlightInfo.Brightness())
+		}
+	}
+
+	broadcasters["intel_backlight"].Stop()
+
+	_, ok = <-first
+	if ok || broadcasters["intel_backlight"].Err() != nil {
+		t.Errorf("expected a stopped broadcaster, got %v", broadcasters["intel_backlight"].Err())
+	}
+}
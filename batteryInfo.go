@@ -121,8 +121,7 @@ func Battery(basepath string) (*BatteryInfo, error) {
 	return &BatteryInfo{PowerSupplyInfo: *powerSupplyInfo}, nil
 }
 
-// Batteries returns all system batteries and their information.
-func Batteries() ([]*BatteryInfo, error) {
+func batteries(dir string) ([]*BatteryInfo, error) {
 	var (
 		powerSupplyInfos []*PowerSupplyInfo
 		batteryInfos     []*BatteryInfo
@@ -130,7 +129,7 @@ func Batteries() ([]*BatteryInfo, error) {
 		err              error
 	)
 
-	powerSupplyInfos, err = PowerSupplies("BAT*")
+	powerSupplyInfos, err = powerSupplies(dir, "BAT*")
 	if err != nil {
 		return nil, err
 	}
@@ -143,3 +142,8 @@ func Batteries() ([]*BatteryInfo, error) {
 
 	return batteryInfos, nil
 }
+
+// Batteries returns all system batteries and their information.
+func Batteries() ([]*BatteryInfo, error) {
+	return batteries(PowerSupplyPath)
+}
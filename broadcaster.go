@@ -0,0 +1,210 @@
+package sstat
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Broadcaster fans out a stream of values, such as the changes of a
+// backlight, to any number of subscribers. Every subscriber has its own
+// buffer, and a full buffer drops its oldest value, so the latest value
+// always wins and a slow subscriber never stalls the publisher or the
+// other subscribers. It is safe for concurrent use.
+type Broadcaster[T any] struct {
+	mutex       sync.Mutex
+	subscribers []chan T
+	latest      T
+	ok          bool
+	closed      bool
+	err         error
+	stop        func()
+}
+
+// NewBroadcaster returns an empty [Broadcaster].
+func NewBroadcaster[T any]() *Broadcaster[T] {
+	return &Broadcaster[T]{}
+}
+
+// Subscribe returns a channel receiving the values published to
+// broadcaster, starting with the latest published value if any. The
+// channel buffers up to buffer values, at least 1, with 1 coalescing
+// every value into the latest one. The returned function unsubscribes
+// and closes the channel. The channel is also closed by
+// [Broadcaster.Close].
+func (broadcaster *Broadcaster[T]) Subscribe(buffer int) (<-chan T, func()) {
+	var valueChan chan T
+
+	valueChan = make(chan T, max(buffer, 1))
+
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	if broadcaster.ok {
+		valueChan <- broadcaster.latest
+	}
+
+	if broadcaster.closed {
+		close(valueChan)
+
+		return valueChan, func() {}
+	}
+
+	broadcaster.subscribers = append(broadcaster.subscribers, valueChan)
+
+	return valueChan, func() {
+		var idx int
+
+		broadcaster.mutex.Lock()
+		defer broadcaster.mutex.Unlock()
+
+		idx = slices.Index(broadcaster.subscribers, valueChan)
+		if idx == -1 {
+			return
+		}
+
+		broadcaster.subscribers = slices.Delete(broadcaster.subscribers, idx, idx+1)
+		close(valueChan)
+	}
+}
+
+// Publish sends value to every subscriber without blocking,
+// dropping the oldest buffered value of full subscribers.
+// Values published after [Broadcaster.Close] are ignored.
+func (broadcaster *Broadcaster[T]) Publish(value T) {
+	var valueChan chan T
+
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	if broadcaster.closed {
+		return
+	}
+
+	broadcaster.latest, broadcaster.ok = value, true
+
+	for _, valueChan = range broadcaster.subscribers {
+		sendLatest(valueChan, value)
+	}
+}
+
+// Latest reports the latest published value
+// and whether if a value was published or not.
+func (broadcaster *Broadcaster[T]) Latest() (value T, ok bool) {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	return broadcaster.latest, broadcaster.ok
+}
+
+// Len reports the number of subscribers.
+func (broadcaster *Broadcaster[T]) Len() (value int) {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	return len(broadcaster.subscribers)
+}
+
+// Close closes the channel of every subscriber after their buffered
+// values, recording err as the reason reported by [Broadcaster.Err].
+// Closing a closed broadcaster does nothing.
+func (broadcaster *Broadcaster[T]) Close(err error) {
+	var valueChan chan T
+
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	if broadcaster.closed {
+		return
+	}
+
+	broadcaster.closed, broadcaster.err = true, err
+
+	for _, valueChan = range broadcaster.subscribers {
+		close(valueChan)
+	}
+
+	broadcaster.subscribers = nil
+}
+
+// Stop stops the source publishing to broadcaster, such as the
+// watcher of a backlight of [BacklightBroadcasters], and closes
+// broadcaster without an error. Stopping a stopped broadcaster
+// does nothing.
+func (broadcaster *Broadcaster[T]) Stop() {
+	var stop func()
+
+	broadcaster.mutex.Lock()
+	stop, broadcaster.stop = broadcaster.stop, nil
+	broadcaster.mutex.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+
+	broadcaster.Close(nil)
+}
+
+// Err reports the error broadcaster was closed with.
+// It is nil while broadcaster is open.
+func (broadcaster *Broadcaster[T]) Err() error {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+
+	return broadcaster.err
+}
+
+// send sends value to valueChan unless ctx is done first
+// and reports whether if value was sent or not.
+func send[T any](ctx context.Context, valueChan chan<- T, value T) (ok bool) {
+	select {
+	case valueChan <- value:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// broadcast publishes the values of valueChan to broadcaster until
+// errChan receives, closing broadcaster with the received error,
+// or until ctx is done.
+func broadcast[T any](ctx context.Context, broadcaster *Broadcaster[T], valueChan <-chan T, errChan <-chan error) {
+	var (
+		value T
+		err   error
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case value = <-valueChan:
+			broadcaster.Publish(value)
+		case err = <-errChan:
+			broadcaster.Close(err)
+
+			return
+		}
+	}
+}
+
+// watchBroadcaster returns a [Broadcaster] of the values of serve,
+// which watches until its ctx is done, stopped by [Broadcaster.Stop].
+func watchBroadcaster[T any](serve func(ctx context.Context, valueChan chan<- T, errChan chan<- error)) *Broadcaster[T] {
+	var (
+		broadcaster *Broadcaster[T]
+		ctx         context.Context
+		valueChan   chan T
+		errChan     chan error
+	)
+
+	broadcaster = NewBroadcaster[T]()
+	ctx, broadcaster.stop = context.WithCancel(context.Background())
+	valueChan = make(chan T)
+	errChan = make(chan error)
+
+	go serve(ctx, valueChan, errChan)
+	go broadcast(ctx, broadcaster, valueChan, errChan)
+
+	return broadcaster
+}
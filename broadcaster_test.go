@@ -0,0 +1,164 @@
+package sstat
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func drain[T any](valueChan <-chan T) []T {
+	var (
+		values []T
+		value  T
+		ok     bool
+	)
+
+	for {
+		select {
+		case value, ok = <-valueChan:
+			if !ok {
+				return values
+			}
+
+			values = append(values, value)
+		default:
+			return values
+		}
+	}
+}
+
+func TestBroadcaster(t *testing.T) {
+	var (
+		broadcaster            *Broadcaster[int]
+		latest, buffered, late <-chan int
+		closed                 <-chan int
+		unsubscribe            func()
+		closeErr               error
+		value                  int
+		ok                     bool
+		tests                  []struct {
+			name string
+			got  []int
+			want []int
+		}
+		idx int
+	)
+
+	broadcaster = NewBroadcaster[int]()
+
+	_, ok = broadcaster.Latest()
+	if ok {
+		t.Error("Latest reported a value before any was published")
+	}
+
+	latest, _ = broadcaster.Subscribe(0)
+	buffered, unsubscribe = broadcaster.Subscribe(3)
+
+	for value = range 5 {
+		broadcaster.Publish(value)
+	}
+
+	late, _ = broadcaster.Subscribe(2)
+
+	broadcaster.Publish(5)
+
+	tests = []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"latest wins", drain(latest), []int{5}},
+		{"drop oldest", drain(buffered), []int{3, 4, 5}},
+		{"late subscriber", drain(late), []int{4, 5}},
+	}
+
+	for idx = range tests {
+		if !reflect.DeepEqual(tests[idx].got, tests[idx].want) {
+			t.Errorf("%s: received %v, want %v", tests[idx].name, tests[idx].got, tests[idx].want)
+		}
+	}
+
+	if broadcaster.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", broadcaster.Len())
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	_, ok = <-buffered
+	if ok || broadcaster.Len() != 2 {
+		t.Error("unsubscribe did not close and remove the subscriber")
+	}
+
+	closeErr = errors.New("watcher failed")
+
+	broadcaster.Publish(6)
+	broadcaster.Close(closeErr)
+	broadcaster.Close(nil)
+	broadcaster.Publish(7)
+
+	if !reflect.DeepEqual(drain(latest), []int{6}) {
+		t.Error("Close dropped the buffered value")
+	}
+
+	_, ok = <-latest
+	if ok || broadcaster.Err() != closeErr {
+		t.Errorf("Close left the subscriber open or lost the error %v", broadcaster.Err())
+	}
+
+	closed, _ = broadcaster.Subscribe(1)
+
+	if !reflect.DeepEqual(drain(closed), []int{6}) {
+		t.Error("Subscribe after Close did not report the latest value")
+	}
+}
+
+func TestBroadcasterStop(t *testing.T) {
+	var (
+		broadcaster *Broadcaster[int]
+		valueChan   <-chan int
+		stopped     chan struct{}
+		value       int
+		ok          bool
+	)
+
+	stopped = make(chan struct{})
+
+	broadcaster = watchBroadcaster(func(ctx context.Context, valueChan chan<- int, errChan chan<- error) {
+		defer close(stopped)
+
+		if !send(ctx, valueChan, 1) {
+			return
+		}
+
+		<-ctx.Done()
+	})
+
+	valueChan, _ = broadcaster.Subscribe(1)
+
+	select {
+	case value = <-valueChan:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the first value")
+	}
+
+	if value != 1 {
+		t.Errorf("expected %d, got %d", 1, value)
+	}
+
+	broadcaster.Stop()
+	broadcaster.Stop()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not stop the source")
+	}
+
+	_, ok = <-valueChan
+	if ok || broadcaster.Err() != nil {
+		t.Errorf("expected a closed subscriber without error, got %v", broadcaster.Err())
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/andrieee44/sstat"
+	"github.com/andrieee44/sstat/statusbar"
+)
+
+// barModules returns the modules of the status line: memory usage,
+// every battery and the preferred backlight of every display among the
+// backlights matching glob, see [sstat.PreferredBacklights].
+func barModules(glob string) ([]*statusbar.Module, error) {
+	var (
+		modules        []*statusbar.Module
+		module         *statusbar.Module
+		batteryInfos   []*sstat.BatteryInfo
+		backlightInfos []*sstat.BacklightInfo
+		preferred      map[string]*sstat.BacklightInfo
+		name           string
+		connector      string
+		ok             bool
+		idx            int
+		err            error
+	)
+
+	modules = append(modules, statusbar.MemoryModule(2*time.Second))
+
+	batteryInfos, err = sstat.Batteries()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx = range batteryInfos {
+		name, ok = batteryInfos[idx].Name()
+		if ok {
+			modules = append(modules, statusbar.BatteryModule(name, 30*time.Second))
+		}
+	}
+
+	backlightInfos, err = sstat.Backlights(glob)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred = sstat.PreferredBacklights(backlightInfos)
+
+	for _, connector = range slices.Sorted(maps.Keys(preferred)) {
+		module, err = statusbar.BacklightModule(preferred[connector].Name())
+		if err != nil {
+			return nil, err
+		}
+
+		modules = append(modules, module)
+	}
+
+	return modules, nil
+}
+
+// runI3bar drives an i3bar or swaybar status line until interrupted.
+func runI3bar(stdout io.Writer, stdin io.Reader, glob string) error {
+	var (
+		modules []*statusbar.Module
+		ctx     context.Context
+		stop    context.CancelFunc
+		err     error
+	)
+
+	modules, err = barModules(glob)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = statusbar.NewI3bar(stdout, stdin, modules...).Run(ctx)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+// backlightName resolves name to the name of a backlight. A display,
+// such as "eDP-1", or a connector, such as "card0-eDP-1", selects the
+// backlight preferred for it, see [sstat.PreferredBacklights], and a
+// blank name selects the backlight preferred for the display of the
+// [sstat.PreferredBacklight]. Other names are taken as backlight names.
+func backlightName(name string) (string, error) {
+	var (
+		backlightInfos []*sstat.BacklightInfo
+		backlightInfo  *sstat.BacklightInfo
+		preferred      map[string]*sstat.BacklightInfo
+		connector      string
+		display        string
+		ok             bool
+		err            error
+	)
+
+	backlightInfos, err = sstat.Backlights("*")
+	if err != nil {
+		return "", err
+	}
+
+	preferred = sstat.PreferredBacklights(backlightInfos)
+
+	if name == "" {
+		backlightInfo, ok = sstat.PreferredBacklight(backlightInfos)
+		if !ok {
+			return "", errors.New("no backlight found")
+		}
+
+		connector, _ = backlightInfo.Connector()
+		if preferred[connector] != nil {
+			backlightInfo = preferred[connector]
+		}
+
+		return backlightInfo.Name(), nil
+	}
+
+	for connector, backlightInfo = range preferred {
+		_, display, _ = strings.Cut(connector, "-")
+		if connector != "" && (name == connector || name == display) {
+			return backlightInfo.Name(), nil
+		}
+	}
+
+	return name, nil
+}
+
+// barModule returns the module kind of a single module bar. A blank
+// name selects the first battery or the preferred backlight, see
+// [backlightName].
+func barModule(kind, name string) (*statusbar.Module, error) {
+	var (
+		batteryInfos []*sstat.BatteryInfo
+		err          error
+	)
+
+	switch kind {
+	case "memory":
+		return statusbar.MemoryModule(2 * time.Second), nil
+	case "battery":
+		if name == "" {
+			batteryInfos, err = sstat.Batteries()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(batteryInfos) == 0 {
+				return nil, errors.New("no battery found")
+			}
+
+			name, _ = batteryInfos[0].Name()
+		}
+
+		return statusbar.BatteryModule(name, 30*time.Second), nil
+	case "backlight":
+		name, err = backlightName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return statusbar.BacklightModule(name)
+	default:
+		return nil, fmt.Errorf("%s: unknown module", kind)
+	}
+}
+
+// runBar feeds a single module to Waybar or Polybar until interrupted.
+func runBar(bar string, stdout io.Writer, args []string) error {
+	var (
+		module *statusbar.Module
+		name   string
+		ctx    context.Context
+		stop   context.CancelFunc
+		err    error
+	)
+
+	if len(args) == 0 {
+		return fmt.Errorf("%s: missing module", bar)
+	}
+
+	if len(args) == 2 {
+		name = args[1]
+	}
+
+	module, err = barModule(args[0], name)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if bar == "waybar" {
+		err = statusbar.Waybar(ctx, stdout, module)
+	} else {
+		err = statusbar.Polybar(ctx, stdout, module)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"text/template"
+)
+
+// funcs are the helper functions available to the format templates.
+var funcs = template.FuncMap{
+	"get":     get,
+	"percent": percent,
+	"human":   human,
+	"kib":     kib,
+}
+
+// get calls the method name of obj and reports its first result. It
+// exists because templates cannot call methods reporting (value, ok),
+// such as [sstat.MemInfo.MemTotal]. Blank is reported if ok is false.
+func get(obj any, name string) (any, error) {
+	var (
+		method  reflect.Value
+		results []reflect.Value
+	)
+
+	method = reflect.ValueOf(obj).MethodByName(name)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%T: no method %s", obj, name)
+	}
+
+	if method.Type().NumIn() != 0 || method.Type().NumOut() == 0 {
+		return nil, fmt.Errorf("%T: method %s is not a getter", obj, name)
+	}
+
+	results = method.Call(nil)
+
+	if len(results) == 2 && results[1].Kind() == reflect.Bool && !results[1].Bool() {
+		return "", nil
+	}
+
+	return results[0].Interface(), nil
+}
+
+// toFloat converts the numbers and numeric strings
+// reported by the readers to float64.
+func toFloat(value any) (float64, error) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	case uint64:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case string:
+		return strconv.ParseFloat(value, 64)
+	default:
+		return 0, fmt.Errorf("%v: not a number", value)
+	}
+}
+
+// percent reports value as a percentage of total.
+func percent(value, total any) (float64, error) {
+	var (
+		num, den float64
+		err      error
+	)
+
+	num, err = toFloat(value)
+	if err != nil {
+		return 0, err
+	}
+
+	den, err = toFloat(total)
+	if err != nil {
+		return 0, err
+	}
+
+	if den == 0 {
+		return 0, nil
+	}
+
+	return num / den * 100, nil
+}
+
+// kib converts a size in kibibytes, such as the
+// values of [sstat.MemInfo], to bytes.
+func kib(value any) (float64, error) {
+	var (
+		num float64
+		err error
+	)
+
+	num, err = toFloat(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return num * 1024, nil
+}
+
+// human formats a size in bytes with binary
+// prefixes, e.g. "1.5 GiB".
+func human(value any) (string, error) {
+	var (
+		num    float64
+		prefix int
+		err    error
+	)
+
+	num, err = toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	for num >= 1024 && prefix < len("KMGTPE") {
+		num /= 1024
+		prefix++
+	}
+
+	if prefix == 0 {
+		return strconv.FormatFloat(num, 'f', -1, 64) + " B", nil
+	}
+
+	return strconv.FormatFloat(num, 'f', 1, 64) + " " + "KMGTPE"[prefix-1:prefix] + "iB", nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+)
+
+type getter struct{}
+
+func (getter) Found() (int, bool) {
+	return 42, true
+}
+
+func (getter) Missing() (string, bool) {
+	return "ignored", false
+}
+
+func (getter) Plain() string {
+	return "plain"
+}
+
+func TestGet(t *testing.T) {
+	var (
+		value any
+		name  string
+		want  any
+		err   error
+	)
+
+	for name, want = range map[string]any{
+		"Found":   42,
+		"Missing": "",
+		"Plain":   "plain",
+	} {
+		value, err = get(getter{}, name)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if value != want {
+			t.Errorf("%s: expected %v, got %v", name, want, value)
+		}
+	}
+
+	_, err = get(getter{}, "Nope")
+	if err == nil {
+		t.Error("expected error for missing method")
+	}
+}
+
+func TestPercent(t *testing.T) {
+	var (
+		value float64
+		err   error
+	)
+
+	value, err = percent("25", 200)
+	if err != nil || value != 12.5 {
+		t.Errorf("expected 12.5, got %v, %v", value, err)
+	}
+
+	value, err = percent(1, 0)
+	if err != nil || value != 0 {
+		t.Errorf("expected 0 for zero total, got %v, %v", value, err)
+	}
+
+	_, err = percent("abc", 1)
+	if err == nil {
+		t.Error("expected error for non-numeric value")
+	}
+}
+
+func TestHuman(t *testing.T) {
+	var (
+		value any
+		want  string
+		size  float64
+		str   string
+		err   error
+	)
+
+	for value, want = range map[any]string{
+		512:             "512 B",
+		1536:            "1.5 KiB",
+		"1073741824":    "1.0 GiB",
+		uint64(1 << 62): "4.0 EiB",
+	} {
+		str, err = human(value)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if str != want {
+			t.Errorf("%v: expected %q, got %q", value, want, str)
+		}
+	}
+
+	size, err = kib(2)
+	if err != nil || size != 2048 {
+		t.Errorf("expected 2048, got %v, %v", size, err)
+	}
+}
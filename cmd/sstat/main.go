@@ -0,0 +1,247 @@
+// Command sstat prints the statistics reported by package sstat
+// using text/template formats.
+//
+// Usage:
+//
+//	sstat [-format template] [-interval duration] command [glob]
+//	sstat i3bar [glob]
+//	sstat waybar|polybar memory|battery|backlight [name]
+//
+// The commands are:
+//
+//	mem           memory usage, see [sstat.MemInfo]
+//	battery       batteries, see [sstat.BatteryInfo]
+//	backlight     backlights matching glob, see [sstat.BacklightInfo]
+//	power-supply  power supplies matching glob, see [sstat.PowerSupplyInfo]
+//	user          the current user, see [sstat.UserInfo]
+//	i3bar         an i3bar or swaybar status line of memory usage, every
+//	              battery and the preferred backlight of every display
+//	              among the backlights matching glob, see
+//	              [statusbar.I3bar]
+//	waybar        a Waybar custom module, see [statusbar.Waybar]
+//	polybar       a Polybar custom/script module, see [statusbar.Polybar]
+//
+// The waybar and polybar commands print a single module: memory usage,
+// the battery name (default: the first battery) or the backlight
+// name, display or connector, such as "eDP-1" (default: the preferred
+// backlight).
+//
+// The template is executed once for every reported device with the
+// device as its data. Besides the builtin functions of text/template,
+// the template may use:
+//
+//	get obj "Method"   the first result of a method reporting (value, ok)
+//	percent a b        a as a percentage of b
+//	kib n              n kibibytes in bytes
+//	human n            n bytes formatted with binary prefixes
+//
+// For example:
+//
+//	sstat -format '{{human (kib (get . "MemAvailable"))}}' mem
+//	sstat -interval 5s -format '{{get . "Capacity"}}%' battery
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// command reads the devices of a subcommand.
+type command struct {
+	format string
+	read   func(glob string) ([]any, error)
+}
+
+func toAny[T any](values []T, err error) ([]any, error) {
+	var (
+		result []any
+		idx    int
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result = make([]any, len(values))
+
+	for idx = range values {
+		result[idx] = values[idx]
+	}
+
+	return result, nil
+}
+
+var commands = map[string]command{
+	"mem": {
+		format: `{{human (kib (get . "MemAvailable"))}} / {{human (kib (get . "MemTotal"))}} available`,
+		read: func(string) ([]any, error) {
+			var (
+				memInfo *sstat.MemInfo
+				err     error
+			)
+
+			memInfo, err = sstat.NewMemInfo()
+
+			return []any{memInfo}, err
+		},
+	},
+	"battery": {
+		format: `{{get . "Name"}}: {{get . "Capacity"}}% {{get . "Status"}}`,
+		read: func(string) ([]any, error) {
+			return toAny(sstat.Batteries())
+		},
+	},
+	"backlight": {
+		format: `{{.Name}}: {{printf "%.0f" (percent .Brightness .MaxBrightness)}}%`,
+		read: func(glob string) ([]any, error) {
+			return toAny(sstat.Backlights(glob))
+		},
+	},
+	"power-supply": {
+		format: `{{get . "Name"}}: {{get . "Type"}}`,
+		read: func(glob string) ([]any, error) {
+			return toAny(sstat.PowerSupplies(glob))
+		},
+	},
+	"user": {
+		format: `{{.Username}}@{{.Hostname}} ({{.Uid}})`,
+		read: func(string) ([]any, error) {
+			var (
+				userInfo *sstat.UserInfo
+				err      error
+			)
+
+			userInfo, err = sstat.CurrentUser()
+
+			return []any{userInfo}, err
+		},
+	},
+}
+
+func render(writer io.Writer, tmpl *template.Template, cmd command, glob string) error {
+	var (
+		values []any
+		idx    int
+		err    error
+	)
+
+	values, err = cmd.read(glob)
+	if err != nil {
+		return err
+	}
+
+	for idx = range values {
+		err = tmpl.Execute(writer, values[idx])
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var (
+		flags    *flag.FlagSet
+		format   string
+		interval time.Duration
+		cmd      command
+		glob     string
+		tmpl     *template.Template
+		ticker   *time.Ticker
+		ok       bool
+		err      error
+	)
+
+	flags = flag.NewFlagSet("sstat", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&format, "format", "", "text/template `template` executed for every device")
+	flags.DurationVar(&interval, "interval", 0, "print repeatedly every `duration` instead of once")
+
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sstat [-format template] [-interval duration] mem|battery|backlight|power-supply|user|i3bar [glob]")
+		fmt.Fprintln(stderr, "       sstat waybar|polybar memory|battery|backlight [name]")
+		flags.PrintDefaults()
+	}
+
+	err = flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 || flags.NArg() > 3 || flags.NArg() == 3 && flags.Arg(0) != "waybar" && flags.Arg(0) != "polybar" {
+		flags.Usage()
+
+		return flag.ErrHelp
+	}
+
+	switch flags.Arg(0) {
+	case "waybar", "polybar":
+		return runBar(flags.Arg(0), stdout, flags.Args()[1:])
+	}
+
+	glob = "*"
+	if flags.NArg() == 2 {
+		glob = flags.Arg(1)
+	}
+
+	if flags.Arg(0) == "i3bar" {
+		return runI3bar(stdout, stdin, glob)
+	}
+
+	cmd, ok = commands[flags.Arg(0)]
+	if !ok {
+		return fmt.Errorf("%s: unknown command", flags.Arg(0))
+	}
+
+	if format == "" {
+		format = cmd.format
+	}
+
+	tmpl, err = template.New(flags.Arg(0)).Funcs(funcs).Parse(format)
+	if err != nil {
+		return err
+	}
+
+	err = render(stdout, tmpl, cmd, glob)
+	if err != nil || interval <= 0 {
+		return err
+	}
+
+	ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err = render(stdout, tmpl, cmd, glob)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	var err error
+
+	err = run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err == flag.ErrHelp {
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sstat:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRender(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		tmpl *template.Template
+		err  error
+	)
+
+	tmpl = template.Must(template.New("test").Funcs(funcs).Parse(`{{get . "Found"}} {{human (kib .)}}`))
+
+	err = render(&buf, template.Must(template.New("test").Funcs(funcs).Parse(`{{.}}`)), command{
+		read: func(glob string) ([]any, error) {
+			return []any{glob, "b"}, nil
+		},
+	}, "a")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if buf.String() != "a\nb\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+
+	err = render(io.Discard, tmpl, command{
+		read: func(string) ([]any, error) {
+			return []any{getter{}}, nil
+		},
+	}, "*")
+	if err == nil {
+		t.Error("expected error for non-numeric kib argument")
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		stdout, stderr bytes.Buffer
+		err            error
+	)
+
+	err = run([]string{"-format", "{{.Username}}", "user"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(stdout.String()) == "" {
+		t.Error("expected username")
+	}
+
+	err = run([]string{"nope"}, nil, io.Discard, io.Discard)
+	if err == nil {
+		t.Error("expected error for unknown command")
+	}
+
+	err = run(nil, nil, io.Discard, io.Discard)
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+
+	err = run([]string{"waybar", "disk"}, nil, io.Discard, io.Discard)
+	if err == nil {
+		t.Error("expected error for unknown bar module")
+	}
+
+	err = run([]string{"polybar"}, nil, io.Discard, io.Discard)
+	if err == nil {
+		t.Error("expected error for missing bar module")
+	}
+
+	err = run([]string{"mem", "a", "b"}, nil, io.Discard, io.Discard)
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected usage error for extra arguments, got %v", err)
+	}
+
+	err = run([]string{"-format", "{{", "mem"}, nil, io.Discard, io.Discard)
+	if err == nil {
+		t.Error("expected template parse error")
+	}
+}
@@ -0,0 +1,349 @@
+package sstat
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Collector reads a subsystem of the system, such as the memory or the
+// batteries, under a name unique within a [Registry]. Collectors may be
+// implemented outside of this package, see [NewCollector].
+type Collector interface {
+	// Name reports the name of the collector, e.g. "memory".
+	Name() string
+
+	// Collect reads the subsystem, e.g. a [*MemInfo]. Callers type-assert
+	// the value to the type documented for the collector, or assert the
+	// collector to a [TypedCollector] and call CollectValue instead.
+	Collect(ctx context.Context) (any, error)
+}
+
+// TypedCollector is a [Collector] of values of type T,
+// such as a [FuncCollector].
+type TypedCollector[T any] interface {
+	Collector
+
+	// CollectValue reads the subsystem as a T.
+	CollectValue(ctx context.Context) (T, error)
+}
+
+// FuncCollector is a [TypedCollector] of values of type T
+// backed by a function, see [NewCollector].
+type FuncCollector[T any] struct {
+	name    string
+	collect func(ctx context.Context) (T, error)
+}
+
+// NewCollector returns a [FuncCollector] named name that reads
+// values of type T with collect.
+func NewCollector[T any](name string, collect func(ctx context.Context) (T, error)) *FuncCollector[T] {
+	return &FuncCollector[T]{
+		name:    name,
+		collect: collect,
+	}
+}
+
+// Name reports the name of the collector.
+func (collector *FuncCollector[T]) Name() string {
+	return collector.name
+}
+
+// Collect reads a value of the collector as an any.
+func (collector *FuncCollector[T]) Collect(ctx context.Context) (any, error) {
+	return collector.CollectValue(ctx)
+}
+
+// CollectValue reads a value of the collector as a T. It returns
+// the error of ctx without reading if ctx is already done.
+func (collector *FuncCollector[T]) CollectValue(ctx context.Context) (T, error) {
+	var zero T
+
+	if ctx.Err() != nil {
+		return zero, ctx.Err()
+	}
+
+	return collector.collect(ctx)
+}
+
+// readerCollector adapts a reader of this package to a [FuncCollector].
+func readerCollector[T any](name string, reader func() (T, error)) *FuncCollector[T] {
+	return NewCollector(name, func(context.Context) (T, error) {
+		return reader()
+	})
+}
+
+// DefaultCollectors returns the builtin collectors, named like the
+// fields of the JSON object of [Snapshot] that they read, except for
+// "batteries" which reads the batteries among the power supplies.
+//
+// Valid values are:
+//   - "host"                  : [*HostInfo] from [Host]
+//   - "virt"                  : [*VirtInfo] from [Virt]
+//   - "user"                  : [*UserInfo] from [CurrentUser]
+//   - "memory"                : [*MemInfo] from [NewMemInfo]
+//   - "huge_pages"            : [][*HugePageInfo] from [HugePages]
+//   - "node_huge_pages"       : [][*HugePageInfo] from [NodeHugePages]
+//   - "transparent_huge_page" : [*TransparentHugePageInfo] from [TransparentHugePage]
+//   - "power_supplies"        : [][*PowerSupplyInfo] from [PowerSupplies]
+//   - "batteries"             : [][*BatteryInfo] from [Batteries]
+//   - "backlights"            : [][*BacklightInfo] from [Backlights]
+//   - "leds"                  : [][*LEDInfo] from [LEDs]
+//   - "rfkills"               : [][*RfkillInfo] from [Rfkills]
+//   - "drm_connectors"        : [][*DRMConnectorInfo] from [DRMConnectors]
+//   - "sound_cards"           : [][*SoundCardInfo] from [SoundCards]
+//   - "pcm_substreams"        : [][*PCMSubstreamInfo] from [PCMSubstreams]
+//   - "input_devices"         : [][*InputDeviceInfo] from [InputDevices]
+//   - "pci_devices"           : [][*PCIDeviceInfo] from [PCIDevices]
+//   - "usb_devices"           : [][*USBDeviceInfo] from [USBDevices]
+//   - "modules"               : [][*ModuleInfo] from [Modules]
+//   - "kernel_taint"          : uint64 from [KernelTaint]
+//   - "sessions"              : [][*UtmpEntry] from [Sessions]
+func DefaultCollectors() []Collector {
+	return []Collector{
+		readerCollector("host", Host),
+		readerCollector("virt", Virt),
+		readerCollector("user", CurrentUser),
+		readerCollector("memory", NewMemInfo),
+		readerCollector("huge_pages", HugePages),
+		readerCollector("node_huge_pages", func() ([]*HugePageInfo, error) { return NodeHugePages("node*") }),
+		readerCollector("transparent_huge_page", TransparentHugePage),
+		readerCollector("power_supplies", func() ([]*PowerSupplyInfo, error) { return PowerSupplies("*") }),
+		readerCollector("batteries", Batteries),
+		readerCollector("backlights", func() ([]*BacklightInfo, error) { return Backlights("*") }),
+		readerCollector("leds", func() ([]*LEDInfo, error) { return LEDs("*") }),
+		readerCollector("rfkills", func() ([]*RfkillInfo, error) { return Rfkills("*") }),
+		readerCollector("drm_connectors", func() ([]*DRMConnectorInfo, error) { return DRMConnectors("*") }),
+		readerCollector("sound_cards", SoundCards),
+		readerCollector("pcm_substreams", PCMSubstreams),
+		readerCollector("input_devices", InputDevices),
+		readerCollector("pci_devices", func() ([]*PCIDeviceInfo, error) { return PCIDevices("*", "") }),
+		readerCollector("usb_devices", func() ([]*USBDeviceInfo, error) { return USBDevices("*", "") }),
+		readerCollector("modules", Modules),
+		readerCollector("kernel_taint", KernelTaint),
+		readerCollector("sessions", Sessions),
+	}
+}
+
+// Sample reports a single value read by a [Collector].
+type Sample struct {
+	// Name is the name of the collector.
+	Name string
+
+	// Time is the time the collector started reading.
+	Time time.Time
+
+	// Value is the value read by the collector. It is nil if Err is not.
+	Value any
+
+	// Err is the error of the collector, if any.
+	Err error
+}
+
+// Registry holds collectors by name, each of which
+// may be disabled. It is safe for concurrent use.
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []Collector
+	disabled   map[string]bool
+}
+
+// DefaultRegistry is the [Registry] of the [DefaultCollectors].
+// Third-party collectors are added with [Registry.Register].
+var DefaultRegistry = NewRegistry(DefaultCollectors()...)
+
+// NewRegistry returns a [Registry] of collectors, all enabled.
+// It panics if two collectors have the same name.
+func NewRegistry(collectors ...Collector) *Registry {
+	var (
+		registry  *Registry
+		collector Collector
+		err       error
+	)
+
+	registry = &Registry{
+		disabled: make(map[string]bool),
+	}
+
+	for _, collector = range collectors {
+		err = registry.Register(collector)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
+
+func (registry *Registry) index(name string) int {
+	return slices.IndexFunc(registry.collectors, func(collector Collector) bool {
+		return collector.Name() == name
+	})
+}
+
+// Register adds the enabled collector to registry. It returns an
+// error if registry already has a collector of the same name.
+func (registry *Registry) Register(collector Collector) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if registry.index(collector.Name()) != -1 {
+		return fmt.Errorf("%s: collector already registered", collector.Name())
+	}
+
+	registry.collectors = append(registry.collectors, collector)
+
+	return nil
+}
+
+// Unregister removes the collector name from registry
+// and reports whether if the collector was registered or not.
+func (registry *Registry) Unregister(name string) (ok bool) {
+	var idx int
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	idx = registry.index(name)
+	if idx == -1 {
+		return false
+	}
+
+	registry.collectors = slices.Delete(registry.collectors, idx, idx+1)
+	delete(registry.disabled, name)
+
+	return true
+}
+
+// Lookup reports the collector name and
+// whether if the collector is registered or not.
+func (registry *Registry) Lookup(name string) (value Collector, ok bool) {
+	var idx int
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	idx = registry.index(name)
+	if idx == -1 {
+		return nil, false
+	}
+
+	return registry.collectors[idx], true
+}
+
+// Names reports the names of every collector of
+// registry, enabled or not, in order of registration.
+func (registry *Registry) Names() (value []string) {
+	var collector Collector
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for _, collector = range registry.collectors {
+		value = append(value, collector.Name())
+	}
+
+	return value
+}
+
+func (registry *Registry) setEnabled(name string, enabled bool) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if registry.index(name) == -1 {
+		return fmt.Errorf("%s: no such collector", name)
+	}
+
+	if enabled {
+		delete(registry.disabled, name)
+	} else {
+		registry.disabled[name] = true
+	}
+
+	return nil
+}
+
+// Enable enables the collector name. It returns an
+// error if registry has no collector of that name.
+func (registry *Registry) Enable(name string) error {
+	return registry.setEnabled(name, true)
+}
+
+// Disable disables the collector name, so that it is skipped by
+// [Registry.Collect]. It returns an error if registry has no
+// collector of that name.
+func (registry *Registry) Disable(name string) error {
+	return registry.setEnabled(name, false)
+}
+
+// Enabled reports whether if the collector name is registered and enabled.
+func (registry *Registry) Enabled(name string) (value bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	return registry.index(name) != -1 && !registry.disabled[name]
+}
+
+// Collectors reports the enabled collectors of registry in order of registration.
+func (registry *Registry) Collectors() (value []Collector) {
+	var collector Collector
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for _, collector = range registry.collectors {
+		if !registry.disabled[collector.Name()] {
+			value = append(value, collector)
+		}
+	}
+
+	return value
+}
+
+// CollectSample reads collector once into a [Sample].
+func CollectSample(ctx context.Context, collector Collector) Sample {
+	var sample Sample
+
+	sample = Sample{
+		Name: collector.Name(),
+		Time: time.Now(),
+	}
+
+	sample.Value, sample.Err = collector.Collect(ctx)
+	if sample.Err != nil {
+		sample.Value = nil
+	}
+
+	return sample
+}
+
+// Collect reads every enabled collector of registry concurrently and
+// reports their samples in order of registration. Errors of the
+// collectors are reported in the samples rather than returned.
+func (registry *Registry) Collect(ctx context.Context) []Sample {
+	var (
+		collectors []Collector
+		samples    []Sample
+		wg         sync.WaitGroup
+		idx        int
+	)
+
+	collectors = registry.Collectors()
+	samples = make([]Sample, len(collectors))
+
+	for idx = range collectors {
+		wg.Add(1)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			samples[idx] = CollectSample(ctx, collectors[idx])
+		}(idx)
+	}
+
+	wg.Wait()
+
+	return samples
+}
@@ -0,0 +1,44 @@
+package sstat_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Register a third-party collector and read every enabled collector.
+func ExampleRegistry_Collect() {
+	var (
+		sample sstat.Sample
+		err    error
+	)
+
+	err = sstat.DefaultRegistry.Register(sstat.NewCollector("loadavg", func(context.Context) (string, error) {
+		var (
+			buf []byte
+			err error
+		)
+
+		buf, err = os.ReadFile("/proc/loadavg")
+
+		return strings.TrimSpace(string(buf)), err
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	sstat.DefaultRegistry.Disable("sessions")
+
+	for _, sample = range sstat.DefaultRegistry.Collect(context.Background()) {
+		if sample.Err != nil {
+			fmt.Printf("%s: %v\n", sample.Name, sample.Err)
+
+			continue
+		}
+
+		fmt.Printf("%s: %T\n", sample.Name, sample.Value)
+	}
+}
@@ -0,0 +1,183 @@
+package sstat
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	var (
+		registry  *Registry
+		collector Collector
+		samples   []Sample
+		ok        bool
+		err       error
+	)
+
+	registry = NewRegistry(
+		NewCollector("answer", func(context.Context) (int, error) { return 42, nil }),
+		NewCollector("broken", func(context.Context) (string, error) { return "partial", errors.New("broken") }),
+	)
+
+	err = registry.Register(NewCollector("answer", func(context.Context) (int, error) { return 0, nil }))
+	if err == nil {
+		t.Error("expected an error for a duplicate collector, got nil")
+	}
+
+	err = registry.Register(NewCollector("name", func(context.Context) (string, error) { return "sstat", nil }))
+	tErrorIf(t, err)
+
+	if !reflect.DeepEqual(registry.Names(), []string{"answer", "broken", "name"}) {
+		t.Errorf("expected %v, got %v", []string{"answer", "broken", "name"}, registry.Names())
+	}
+
+	samples = registry.Collect(context.Background())
+	if len(samples) != 3 || samples[0].Value != 42 || samples[1].Err == nil || samples[1].Value != nil || samples[2].Value != "sstat" {
+		t.Errorf("expected samples 42, an error and sstat, got %+v", samples)
+	}
+
+	err = registry.Disable("broken")
+	tErrorIf(t, err)
+
+	if registry.Enabled("broken") || !registry.Enabled("answer") || registry.Enabled("missing") {
+		t.Error("expected only broken to be disabled, got a different Enabled()")
+	}
+
+	samples = registry.Collect(context.Background())
+	if len(samples) != 2 || samples[0].Name != "answer" || samples[1].Name != "name" {
+		t.Errorf("expected samples of answer and name, got %+v", samples)
+	}
+
+	err = registry.Enable("broken")
+	tErrorIf(t, err)
+
+	if len(registry.Collectors()) != 3 {
+		t.Errorf("expected 3 collectors, got %d", len(registry.Collectors()))
+	}
+
+	err = registry.Disable("missing")
+	if err == nil {
+		t.Error("expected an error for an unknown collector, got nil")
+	}
+
+	collector, ok = registry.Lookup("name")
+	if !ok || collector.Name() != "name" {
+		t.Errorf("expected the name collector, got %v, %t", collector, ok)
+	}
+
+	if !registry.Unregister("name") || registry.Unregister("name") {
+		t.Error("expected Unregister to remove the collector once, got a different result")
+	}
+
+	_, ok = registry.Lookup("name")
+	if ok {
+		t.Error("expected no unregistered collector, got one")
+	}
+}
+
+func TestFuncCollectorCanceled(t *testing.T) {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		called bool
+		err    error
+	)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	_, err = NewCollector("canceled", func(context.Context) (int, error) {
+		called = true
+
+		return 1, nil
+	}).CollectValue(ctx)
+
+	if called || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v without a read, got %v, called %t", context.Canceled, err, called)
+	}
+}
+
+func TestTypedCollector(t *testing.T) {
+	var (
+		collector Collector
+		typed     TypedCollector[int]
+		value     int
+		ok        bool
+		err       error
+	)
+
+	collector = NewCollector("answer", func(context.Context) (int, error) { return 42, nil })
+
+	typed, ok = collector.(TypedCollector[int])
+	if !ok {
+		t.Fatalf("expected a TypedCollector[int], got %T", collector)
+	}
+
+	value, err = typed.CollectValue(context.Background())
+	tErrorIf(t, err)
+
+	if value != 42 {
+		t.Errorf("expected 42, got %d", value)
+	}
+
+	_, ok = collector.(TypedCollector[string])
+	if ok {
+		t.Error("expected no TypedCollector[string], got one")
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	var (
+		collector Collector
+		sample    Sample
+		memory    *MemInfo
+		ok        bool
+	)
+
+	if !checkPath(t, MemInfoPath) {
+		return
+	}
+
+	collector, ok = DefaultRegistry.Lookup("memory")
+	if !ok {
+		t.Fatal("expected the memory collector, got none")
+	}
+
+	sample = CollectSample(context.Background(), collector)
+	tErrorIf(t, sample.Err)
+
+	memory, ok = sample.Value.(*MemInfo)
+	if sample.Name != "memory" || !ok || memory == nil {
+		t.Errorf("expected a *MemInfo sample, got %+v", sample)
+	}
+
+	if len(DefaultRegistry.Names()) != len(DefaultCollectors()) {
+		t.Errorf("expected %d collectors, got %d", len(DefaultCollectors()), len(DefaultRegistry.Names()))
+	}
+}
+
+func TestDefaultCollectorsSnapshot(t *testing.T) {
+	var (
+		typ  reflect.Type
+		name string
+		ok   bool
+		idx  int
+	)
+
+	typ = reflect.TypeFor[Snapshot]()
+
+	for idx = range typ.NumField() {
+		name, _, _ = strings.Cut(typ.Field(idx).Tag.Get("json"), ",")
+		if name == "version" || name == "time" || name == "errors" {
+			continue
+		}
+
+		_, ok = DefaultRegistry.Lookup(name)
+		if !ok {
+			t.Errorf("expected a collector of the snapshot field %q, got none", name)
+		}
+	}
+}
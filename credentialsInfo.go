@@ -0,0 +1,343 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/bits"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// capNames are the capability names from linux/capability.h
+// indexed by their bit number.
+var capNames = []string{
+	"cap_chown",
+	"cap_dac_override",
+	"cap_dac_read_search",
+	"cap_fowner",
+	"cap_fsetid",
+	"cap_kill",
+	"cap_setgid",
+	"cap_setuid",
+	"cap_setpcap",
+	"cap_linux_immutable",
+	"cap_net_bind_service",
+	"cap_net_broadcast",
+	"cap_net_admin",
+	"cap_net_raw",
+	"cap_ipc_lock",
+	"cap_ipc_owner",
+	"cap_sys_module",
+	"cap_sys_rawio",
+	"cap_sys_chroot",
+	"cap_sys_ptrace",
+	"cap_sys_pacct",
+	"cap_sys_admin",
+	"cap_sys_boot",
+	"cap_sys_nice",
+	"cap_sys_resource",
+	"cap_sys_time",
+	"cap_sys_tty_config",
+	"cap_mknod",
+	"cap_lease",
+	"cap_audit_write",
+	"cap_audit_control",
+	"cap_setfcap",
+	"cap_mac_override",
+	"cap_mac_admin",
+	"cap_syslog",
+	"cap_wake_alarm",
+	"cap_block_suspend",
+	"cap_audit_read",
+	"cap_perfmon",
+	"cap_bpf",
+	"cap_checkpoint_restore",
+}
+
+// CapNames reports the names of the capabilities set in mask, e.g.
+// "cap_sys_admin". Capabilities unknown to this package are
+// reported by their bit number, e.g. "cap_41".
+func CapNames(mask uint64) []string {
+	var (
+		names []string
+		bit   int
+	)
+
+	for mask != 0 {
+		bit = bits.TrailingZeros64(mask)
+		mask &^= 1 << bit
+
+		if bit < len(capNames) {
+			names = append(names, capNames[bit])
+
+			continue
+		}
+
+		names = append(names, "cap_"+strconv.Itoa(bit))
+	}
+
+	return names
+}
+
+// IdMapping reports a single line of a user namespace
+// uid_map or gid_map. Documentation for the object methods are taken
+// from [user_namespaces(7)].
+//
+// [user_namespaces(7)]: https://man.archlinux.org/man/user_namespaces.7.en
+type IdMapping struct {
+	inside, outside, count int64
+}
+
+// Inside reports the start of the range of IDs in the
+// user namespace of the process.
+func (mapping *IdMapping) Inside() (value int64) {
+	return mapping.inside
+}
+
+// Outside reports the start of the range of IDs to which the
+// IDs inside the namespace map in the namespace of the reader.
+func (mapping *IdMapping) Outside() (value int64) {
+	return mapping.outside
+}
+
+// Count reports the length of the range of IDs
+// that is mapped between the two namespaces.
+func (mapping *IdMapping) Count() (value int64) {
+	return mapping.count
+}
+
+// CredentialsInfo reports the credentials of a process from
+// [ProcPath]/<pid>/status, uid_map and gid_map. Documentation for the
+// object methods are taken from [proc_pid_status(5)].
+//
+// [proc_pid_status(5)]: https://man.archlinux.org/man/proc_pid_status.5.en
+type CredentialsInfo struct {
+	uids, gids             [4]int
+	groups                 []int
+	nsPid, nsTgid          []int
+	capInh, capPrm, capEff uint64
+	capBnd, capAmb         uint64
+	uidMap, gidMap         []*IdMapping
+}
+
+// Uids reports the real, effective, saved set, and
+// filesystem UIDs of the process.
+func (info *CredentialsInfo) Uids() (real, effective, saved, fs int) {
+	return info.uids[0], info.uids[1], info.uids[2], info.uids[3]
+}
+
+// Gids reports the real, effective, saved set, and
+// filesystem GIDs of the process.
+func (info *CredentialsInfo) Gids() (real, effective, saved, fs int) {
+	return info.gids[0], info.gids[1], info.gids[2], info.gids[3]
+}
+
+// Groups reports the supplementary group list of the process.
+func (info *CredentialsInfo) Groups() (value []int) {
+	return info.groups
+}
+
+// NSpid reports the thread ID in each of the PID namespaces
+// of which the process is a member, outermost first.
+func (info *CredentialsInfo) NSpid() (value []int) {
+	return info.nsPid
+}
+
+// NStgid reports the thread group ID in each of the PID
+// namespaces of which the process is a member, outermost first.
+func (info *CredentialsInfo) NStgid() (value []int) {
+	return info.nsTgid
+}
+
+// CapInh reports the mask of capabilities enabled in the inheritable set.
+func (info *CredentialsInfo) CapInh() (value uint64) {
+	return info.capInh
+}
+
+// CapPrm reports the mask of capabilities enabled in the permitted set.
+func (info *CredentialsInfo) CapPrm() (value uint64) {
+	return info.capPrm
+}
+
+// CapEff reports the mask of capabilities enabled in the effective set.
+// See [CapNames] for decoding the mask.
+func (info *CredentialsInfo) CapEff() (value uint64) {
+	return info.capEff
+}
+
+// CapBnd reports the capability bounding set.
+func (info *CredentialsInfo) CapBnd() (value uint64) {
+	return info.capBnd
+}
+
+// CapAmb (since Linux 4.3) reports the ambient capability set.
+func (info *CredentialsInfo) CapAmb() (value uint64) {
+	return info.capAmb
+}
+
+// UidMap reports the mapping of user IDs from the user namespace
+// of the process to the user namespace of the reader.
+// The value is nil if the kernel lacks user namespace support.
+func (info *CredentialsInfo) UidMap() (value []*IdMapping) {
+	return info.uidMap
+}
+
+// GidMap reports the mapping of group IDs from the user namespace
+// of the process to the user namespace of the reader.
+func (info *CredentialsInfo) GidMap() (value []*IdMapping) {
+	return info.gidMap
+}
+
+func parseIntFields(fields []string) ([]int, error) {
+	var (
+		nums []int
+		idx  int
+		err  error
+	)
+
+	nums = make([]int, len(fields))
+
+	for idx = range fields {
+		nums[idx], err = strconv.Atoi(fields[idx])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nums, nil
+}
+
+func idMappings(path string) ([]*IdMapping, error) {
+	var (
+		mappings []*IdMapping
+		err      error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			mapping *IdMapping
+			fields  []string
+			value   *int64
+			idx     int
+			err     error
+		)
+
+		fields = strings.Fields(text)
+		if len(fields) != 3 {
+			return false, fmt.Errorf("%s: invalid id map format", path)
+		}
+
+		mapping = new(IdMapping)
+
+		for idx, value = range []*int64{&mapping.inside, &mapping.outside, &mapping.count} {
+			*value, err = strconv.ParseInt(fields[idx], 10, 64)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		mappings = append(mappings, mapping)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+func credentials(dir string) (*CredentialsInfo, error) {
+	var (
+		credentialsInfo *CredentialsInfo
+		path            string
+		err             error
+	)
+
+	credentialsInfo = new(CredentialsInfo)
+	path = filepath.Join(dir, "status")
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			key, value string
+			nums       []int
+			ok         bool
+			err        error
+		)
+
+		key, value, ok = strings.Cut(text, ":")
+		if !ok {
+			return false, fmt.Errorf("%s: invalid status format", path)
+		}
+
+		switch key {
+		case "Uid", "Gid":
+			nums, err = parseIntFields(strings.Fields(value))
+			if err != nil {
+				return false, fmt.Errorf("%s: %s: %w", path, key, err)
+			}
+
+			if len(nums) != 4 {
+				return false, fmt.Errorf("%s: %s: invalid status format", path, key)
+			}
+
+			if key == "Uid" {
+				credentialsInfo.uids = [4]int(nums)
+			} else {
+				credentialsInfo.gids = [4]int(nums)
+			}
+		case "Groups":
+			credentialsInfo.groups, err = parseIntFields(strings.Fields(value))
+		case "NSpid":
+			credentialsInfo.nsPid, err = parseIntFields(strings.Fields(value))
+		case "NStgid":
+			credentialsInfo.nsTgid, err = parseIntFields(strings.Fields(value))
+		case "CapInh":
+			credentialsInfo.capInh, err = strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		case "CapPrm":
+			credentialsInfo.capPrm, err = strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		case "CapEff":
+			credentialsInfo.capEff, err = strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		case "CapBnd":
+			credentialsInfo.capBnd, err = strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		case "CapAmb":
+			credentialsInfo.capAmb, err = strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsInfo.uidMap, err = idMappings(filepath.Join(dir, "uid_map"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	credentialsInfo.gidMap, err = idMappings(filepath.Join(dir, "gid_map"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return credentialsInfo, nil
+}
+
+// Credentials returns the credentials of the process pid
+// from [ProcPath]/<pid>.
+func Credentials(pid int) (*CredentialsInfo, error) {
+	return credentials(filepath.Join(ProcPath, strconv.Itoa(pid)))
+}
+
+// SelfCredentials returns the credentials of the current process
+// from [ProcPath]/self.
+func SelfCredentials() (*CredentialsInfo, error) {
+	return credentials(filepath.Join(ProcPath, "self"))
+}
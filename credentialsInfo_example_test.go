@@ -0,0 +1,22 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the effective capabilities of the current process.
+func ExampleSelfCredentials() {
+	var (
+		credentialsInfo *sstat.CredentialsInfo
+		err             error
+	)
+
+	credentialsInfo, err = sstat.SelfCredentials()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(sstat.CapNames(credentialsInfo.CapEff()))
+}
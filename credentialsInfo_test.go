@@ -0,0 +1,78 @@
+package sstat
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCapNames(t *testing.T) {
+	var names []string
+
+	names = CapNames(1<<21 | 1<<12 | 1<<63)
+	if !slices.Equal(names, []string{"cap_net_admin", "cap_sys_admin", "cap_63"}) {
+		t.Errorf("unexpected capability names %v", names)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	var (
+		root            string
+		credentialsInfo *CredentialsInfo
+		uid, euid       int
+		err             error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"status":  "Name:\tbash\nUid:\t1000\t0\t0\t0\nGid:\t1000\t1000\t1000\t1000\nGroups:\t10 998 \nNStgid:\t4242\t1\nNSpid:\t4242\t1\nCapInh:\t0000000000000000\nCapEff:\t0000000000200000\n",
+		"uid_map": "         0     100000      65536\n",
+	})
+
+	credentialsInfo, err = credentials(root)
+	tErrorIf(t, err)
+
+	uid, euid, _, _ = credentialsInfo.Uids()
+	if uid != 1000 || euid != 0 {
+		t.Errorf("expected uids %d %d, got %d %d", 1000, 0, uid, euid)
+	}
+
+	if !slices.Equal(credentialsInfo.Groups(), []int{10, 998}) || !slices.Equal(credentialsInfo.NSpid(), []int{4242, 1}) {
+		t.Errorf("unexpected credentials %+v", *credentialsInfo)
+	}
+
+	if !slices.Equal(CapNames(credentialsInfo.CapEff()), []string{"cap_sys_admin"}) {
+		t.Errorf("unexpected effective capabilities %v", CapNames(credentialsInfo.CapEff()))
+	}
+
+	if len(credentialsInfo.UidMap()) != 1 || credentialsInfo.UidMap()[0].Outside() != 100000 || credentialsInfo.GidMap() != nil {
+		t.Errorf("unexpected id maps %v %v", credentialsInfo.UidMap(), credentialsInfo.GidMap())
+	}
+
+	_, err = SelfCredentials()
+	tErrorIf(t, err)
+}
+
+func TestCredentialsInvalid(t *testing.T) {
+	var (
+		tests = []struct {
+			name, status string
+		}{
+			{"missing colon", "Name bash\n"},
+			{"short Uid", "Uid:\t1000\t0\n"},
+			{"invalid CapEff", "CapEff:\tzz\n"},
+		}
+		root string
+		idx  int
+		err  error
+	)
+
+	for idx = range tests {
+		root = tmpTree(t, map[string]string{"status": tests[idx].status})
+
+		_, err = credentials(root)
+		if err == nil || !strings.Contains(err.Error(), filepath.Join(root, "status")) {
+			t.Errorf("%s: expected an error naming %s, got %v", tests[idx].name, filepath.Join(root, "status"), err)
+		}
+	}
+}
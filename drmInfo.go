@@ -0,0 +1,194 @@
+package sstat
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DRMPath is the directory where the information for
+// DRM devices and their connectors are located.
+const DRMPath string = "/sys/class/drm"
+
+// DRMConnectorInfo reports DRM connector information, i.e. a
+// display output of a graphics card. Documentation for the
+// object methods are taken from [sysfs-class-drm].
+//
+// [sysfs-class-drm]: https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-drm
+type DRMConnectorInfo struct {
+	name      string
+	status    string
+	enabled   string
+	dpms      string
+	modes     []string
+	edid      *EDIDInfo
+	backlight *BacklightInfo
+}
+
+// Name reports the name of the connector, e.g. "card0-eDP-1".
+func (info *DRMConnectorInfo) Name() (value string) {
+	return info.name
+}
+
+// Display reports the name of the connector as named
+// by display servers, e.g. "eDP-1".
+func (info *DRMConnectorInfo) Display() (value string) {
+	_, value, _ = strings.Cut(info.name, "-")
+
+	return value
+}
+
+// Status reports whether a display is attached to the connector.
+//
+// Valid values are:
+//   - "connected"
+//   - "disconnected"
+//   - "unknown"
+func (info *DRMConnectorInfo) Status() (value string) {
+	return info.status
+}
+
+// Connected reports whether a display is attached to the connector.
+func (info *DRMConnectorInfo) Connected() bool {
+	return info.status == "connected"
+}
+
+// Enabled reports whether the connector is driven by a CRTC.
+//
+// Valid values are:
+//   - "enabled"
+//   - "disabled"
+func (info *DRMConnectorInfo) Enabled() (value string) {
+	return info.enabled
+}
+
+// Dpms reports the DPMS power state of the connector.
+//
+// Valid values are:
+//   - "On"
+//   - "Standby"
+//   - "Suspend"
+//   - "Off"
+func (info *DRMConnectorInfo) Dpms() (value string) {
+	return info.dpms
+}
+
+// Modes reports the modes supported by the attached display,
+// e.g. "1920x1080", preferred mode first.
+func (info *DRMConnectorInfo) Modes() (value []string) {
+	return info.modes
+}
+
+// EDID reports the identification data of the attached display
+// and whether if the display provides valid identification data or not.
+func (info *DRMConnectorInfo) EDID() (value *EDIDInfo, ok bool) {
+	return info.edid, info.edid != nil
+}
+
+// Backlight reports the backlight of the panel attached to the connector
+// and whether if the panel has a backlight or not.
+func (info *DRMConnectorInfo) Backlight() (value *BacklightInfo, ok bool) {
+	return info.backlight, info.backlight != nil
+}
+
+func drmConnector(dir, basepath string) (*DRMConnectorInfo, error) {
+	var (
+		connectorInfo *DRMConnectorInfo
+		key           string
+		value         *string
+		buf           []byte
+		err           error
+	)
+
+	connectorInfo = &DRMConnectorInfo{
+		name: basepath,
+	}
+
+	for key, value = range map[string]*string{
+		"status":  &connectorInfo.status,
+		"enabled": &connectorInfo.enabled,
+		"dpms":    &connectorInfo.dpms,
+	} {
+		*value, err = PathReadStr(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ScanFile(filepath.Join(dir, basepath, "modes"), bufio.ScanLines, func(text string) (bool, error) {
+		connectorInfo.modes = append(connectorInfo.modes, text)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err = os.ReadFile(filepath.Join(dir, basepath, "edid"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) != 0 {
+		connectorInfo.edid, _ = ParseEDID(buf)
+	}
+
+	return connectorInfo, nil
+}
+
+func drmConnectors(dir, glob, backlightDir string) ([]*DRMConnectorInfo, error) {
+	var (
+		connectorPaths []string
+		connectorInfos []*DRMConnectorInfo
+		panels         []string
+		backlightInfos []*BacklightInfo
+		preferred      map[string]*BacklightInfo
+		idx            int
+		err            error
+	)
+
+	connectorPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	panels, err = panelConnectors(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	backlightInfos, err = backlights(backlightDir, "*")
+	if err != nil {
+		return nil, err
+	}
+
+	preferred = preferredBacklights(backlightsByConnector(backlightInfos, panels))
+	connectorInfos = make([]*DRMConnectorInfo, len(connectorPaths))
+
+	for idx = range connectorPaths {
+		connectorInfos[idx], err = drmConnector(dir, filepath.Base(connectorPaths[idx]))
+		if err != nil {
+			return nil, err
+		}
+
+		connectorInfos[idx].backlight = preferred[connectorInfos[idx].name]
+	}
+
+	return connectorInfos, nil
+}
+
+// DRMConnector returns DRM connector information in [DRMPath] + basepath.
+// The connector is not linked to its backlight, see [DRMConnectors].
+func DRMConnector(basepath string) (*DRMConnectorInfo, error) {
+	return drmConnector(DRMPath, basepath)
+}
+
+// DRMConnectors returns all DRM connector information in [DRMPath] + glob,
+// such as "card*-*". Each connector is linked to the [PreferredBacklight]
+// of the backlights in [BacklightPath] controlling its panel, see
+// [BacklightsByConnector]. Unlinked firmware and platform backlights
+// are linked to the internal panel if there is exactly one.
+func DRMConnectors(glob string) ([]*DRMConnectorInfo, error) {
+	return drmConnectors(DRMPath, glob, BacklightPath)
+}
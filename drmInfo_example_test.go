@@ -0,0 +1,40 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the connected displays and their preferred resolution.
+func ExampleDRMConnectors() {
+	var (
+		connectorInfos []*sstat.DRMConnectorInfo
+		edidInfo       *sstat.EDIDInfo
+		width, height  int
+		idx            int
+		ok             bool
+		err            error
+	)
+
+	connectorInfos, err = sstat.DRMConnectors("card*-*")
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range connectorInfos {
+		if !connectorInfos[idx].Connected() {
+			continue
+		}
+
+		edidInfo, ok = connectorInfos[idx].EDID()
+		if !ok {
+			fmt.Println(connectorInfos[idx].Display())
+
+			continue
+		}
+
+		width, height = edidInfo.PreferredResolution()
+		fmt.Printf("%s: %s %s %dx%d\n", connectorInfos[idx].Display(), edidInfo.Manufacturer(), edidInfo.Model(), width, height)
+	}
+}
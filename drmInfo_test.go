@@ -0,0 +1,112 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDRMConnectors(t *testing.T) {
+	var (
+		root           string
+		edid           []byte
+		connectorInfos []*DRMConnectorInfo
+		backlightInfo  *BacklightInfo
+		name, content  string
+		ok             bool
+		err            error
+	)
+
+	edid, err = os.ReadFile(filepath.Join("testdata", "edid"))
+	tErrorIf(t, err)
+
+	root = tmpTree(t, map[string]string{
+		"drm/card0-eDP-1/status":                      "connected\n",
+		"drm/card0-eDP-1/enabled":                     "enabled\n",
+		"drm/card0-eDP-1/dpms":                        "On\n",
+		"drm/card0-eDP-1/modes":                       "1920x1080\n1680x1050\n",
+		"drm/card0-eDP-1/edid":                        string(edid),
+		"drm/card0-HDMI-A-1/status":                   "disconnected\n",
+		"drm/card0-HDMI-A-1/enabled":                  "disabled\n",
+		"drm/card0-HDMI-A-1/dpms":                     "Off\n",
+		"drm/card0-HDMI-A-1/modes":                    "",
+		"drm/card0-HDMI-A-1/edid":                     "",
+		"drm/version":                                 "drm 1.1.0 20060810\n",
+		"backlight/intel_backlight/brightness":        "1\n",
+		"backlight/intel_backlight/actual_brightness": "1\n",
+		"backlight/intel_backlight/max_brightness":    "2\n",
+		"backlight/intel_backlight/bl_power":          "0\n",
+		"backlight/intel_backlight/type":              "raw\n",
+	})
+
+	tErrorIf(t, os.Symlink(filepath.Join(root, "drm", "card0-eDP-1"), filepath.Join(root, "backlight", "intel_backlight", "device")))
+
+	connectorInfos, err = drmConnectors(filepath.Join(root, "drm"), "card*-*", filepath.Join(root, "backlight"))
+	tErrorIf(t, err)
+
+	if len(connectorInfos) != 2 {
+		t.Fatalf("expected %d connectors, got %d", 2, len(connectorInfos))
+	}
+
+	if connectorInfos[0].Display() != "HDMI-A-1" || connectorInfos[0].Connected() || connectorInfos[0].Modes() != nil {
+		t.Errorf("unexpected connector %+v", *connectorInfos[0])
+	}
+
+	_, ok = connectorInfos[0].EDID()
+	if ok {
+		t.Errorf("expected %s to have no edid", connectorInfos[0].Name())
+	}
+
+	if !connectorInfos[1].Connected() || !slices.Equal(connectorInfos[1].Modes(), []string{"1920x1080", "1680x1050"}) {
+		t.Errorf("unexpected connector %+v", *connectorInfos[1])
+	}
+
+	backlightInfo, ok = connectorInfos[1].Backlight()
+	if !ok || backlightInfo.Name() != "intel_backlight" {
+		t.Errorf("expected %s to be linked to %q", connectorInfos[1].Name(), "intel_backlight")
+	}
+
+	for name, content = range map[string]string{"brightness": "1\n", "actual_brightness": "1\n", "max_brightness": "2\n", "bl_power": "0\n", "type": "firmware\n"} {
+		tErrorIf(t, os.MkdirAll(filepath.Join(root, "backlight", "acpi_video0"), 0o755))
+		tErrorIf(t, os.WriteFile(filepath.Join(root, "backlight", "acpi_video0", name), []byte(content), 0o644))
+	}
+
+	connectorInfos, err = drmConnectors(filepath.Join(root, "drm"), "card*-*", filepath.Join(root, "backlight"))
+	tErrorIf(t, err)
+
+	backlightInfo, ok = connectorInfos[1].Backlight()
+	if !ok || backlightInfo.Name() != "acpi_video0" {
+		t.Errorf("expected %s to be linked to %q, got %v", connectorInfos[1].Name(), "acpi_video0", backlightInfo)
+	}
+
+	_, ok = connectorInfos[0].Backlight()
+	if ok {
+		t.Errorf("expected %s to have no backlight", connectorInfos[0].Name())
+	}
+
+	tErrorIf(t, os.RemoveAll(filepath.Join(root, "backlight", "intel_backlight")))
+
+	connectorInfos, err = drmConnectors(filepath.Join(root, "drm"), "card*-HDMI-*", filepath.Join(root, "backlight"))
+	tErrorIf(t, err)
+
+	_, ok = connectorInfos[0].Backlight()
+	if ok {
+		t.Errorf("expected %s to have no backlight", connectorInfos[0].Name())
+	}
+
+	connectorInfos, err = drmConnectors(filepath.Join(root, "drm"), "card*-eDP-*", filepath.Join(root, "backlight"))
+	tErrorIf(t, err)
+
+	backlightInfo, ok = connectorInfos[0].Backlight()
+	if !ok || backlightInfo.Name() != "acpi_video0" {
+		t.Errorf("expected %s to be linked to %q without a raw backlight, got %v", connectorInfos[0].Name(), "acpi_video0", backlightInfo)
+	}
+
+	if !checkPath(t, DRMPath) {
+		return
+	}
+
+	_, err = DRMConnectors("card*-*")
+	tErrorIf(t, err)
+}
@@ -0,0 +1,166 @@
+package sstat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// edidHeader is the fixed pattern starting every EDID base block.
+var edidHeader = []byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+
+// EDIDInfo reports display identification data from the base block of an
+// EDID 1.3 or 1.4 structure. Documentation for the object methods are taken
+// from [E-EDID].
+//
+// [E-EDID]: https://en.wikipedia.org/wiki/Extended_Display_Identification_Data
+type EDIDInfo struct {
+	manufacturer     string
+	productCode      int
+	serialNumber     uint32
+	week, year       int
+	widthCm          int
+	heightCm         int
+	name             string
+	serial           string
+	preferredWidth   int
+	preferredHeight  int
+	preferredClockHz int
+}
+
+// Manufacturer reports the three letter PNP ID of the
+// manufacturer, e.g. "BOE" or "DEL".
+func (info *EDIDInfo) Manufacturer() (value string) {
+	return info.manufacturer
+}
+
+// ProductCode reports the manufacturer product code.
+func (info *EDIDInfo) ProductCode() (value int) {
+	return info.productCode
+}
+
+// SerialNumber reports the numeric serial number. It is 0 if unused.
+func (info *EDIDInfo) SerialNumber() (value uint32) {
+	return info.serialNumber
+}
+
+// Serial reports the serial number string descriptor.
+// It is blank if the display has none.
+func (info *EDIDInfo) Serial() (value string) {
+	return info.serial
+}
+
+// Model reports the display name descriptor, e.g. "DELL U2720Q".
+// It is blank if the display has none.
+func (info *EDIDInfo) Model() (value string) {
+	return info.name
+}
+
+// Week reports the week of manufacture, or 0 if unspecified.
+func (info *EDIDInfo) Week() (value int) {
+	return info.week
+}
+
+// Year reports the year of manufacture.
+func (info *EDIDInfo) Year() (value int) {
+	return info.year
+}
+
+// Size reports the physical size of the display in centimeters.
+// Both values are 0 for projectors and displays of variable size.
+func (info *EDIDInfo) Size() (width, height int) {
+	return info.widthCm, info.heightCm
+}
+
+// PreferredResolution reports the resolution of the
+// preferred timing mode in pixels.
+func (info *EDIDInfo) PreferredResolution() (width, height int) {
+	return info.preferredWidth, info.preferredHeight
+}
+
+// PreferredPixelClock reports the pixel clock of the
+// preferred timing mode in hertz.
+func (info *EDIDInfo) PreferredPixelClock() (value int) {
+	return info.preferredClockHz
+}
+
+func edidString(buf []byte) string {
+	var idx int
+
+	idx = bytes.IndexByte(buf, '\n')
+	if idx != -1 {
+		buf = buf[:idx]
+	}
+
+	return strings.TrimSpace(string(buf))
+}
+
+// ParseEDID decodes the base block of the EDID structure in buf,
+// such as the contents of the edid file of a DRM connector.
+func ParseEDID(buf []byte) (*EDIDInfo, error) {
+	var (
+		edidInfo *EDIDInfo
+		id       uint16
+		sum      byte
+		desc     []byte
+		idx      int
+	)
+
+	if len(buf) < 128 {
+		return nil, errors.New("edid: truncated base block")
+	}
+
+	if !bytes.Equal(buf[:8], edidHeader) {
+		return nil, errors.New("edid: invalid header")
+	}
+
+	for idx = range 128 {
+		sum += buf[idx]
+	}
+
+	if sum != 0 {
+		return nil, errors.New("edid: invalid checksum")
+	}
+
+	id = binary.BigEndian.Uint16(buf[8:10])
+
+	edidInfo = &EDIDInfo{
+		manufacturer: string([]byte{
+			byte(id>>10&0x1f) + 'A' - 1,
+			byte(id>>5&0x1f) + 'A' - 1,
+			byte(id&0x1f) + 'A' - 1,
+		}),
+		productCode:  int(binary.LittleEndian.Uint16(buf[10:12])),
+		serialNumber: binary.LittleEndian.Uint32(buf[12:16]),
+		week:         int(buf[16]),
+		year:         int(buf[17]) + 1990,
+		widthCm:      int(buf[21]),
+		heightCm:     int(buf[22]),
+	}
+
+	for idx = 54; idx < 126; idx += 18 {
+		desc = buf[idx : idx+18]
+
+		if desc[0] != 0 || desc[1] != 0 {
+			if edidInfo.preferredWidth != 0 {
+				continue
+			}
+
+			edidInfo.preferredClockHz = int(binary.LittleEndian.Uint16(desc[0:2])) * 10000
+			edidInfo.preferredWidth = int(desc[2]) | int(desc[4]&0xf0)<<4
+			edidInfo.preferredHeight = int(desc[5]) | int(desc[7]&0xf0)<<4
+
+			continue
+		}
+
+		switch desc[3] {
+		case 0xfc:
+			edidInfo.name = edidString(desc[5:])
+		case 0xff:
+			edidInfo.serial = edidString(desc[5:])
+		}
+	}
+
+	return edidInfo, nil
+}
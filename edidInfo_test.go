@@ -0,0 +1,48 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseEDID(t *testing.T) {
+	var (
+		buf           []byte
+		edidInfo      *EDIDInfo
+		width, height int
+		err           error
+	)
+
+	buf, err = os.ReadFile(filepath.Join("testdata", "edid"))
+	tErrorIf(t, err)
+
+	edidInfo, err = ParseEDID(buf)
+	tErrorIf(t, err)
+
+	if edidInfo.Manufacturer() != "BOE" || edidInfo.ProductCode() != 0x0747 || edidInfo.Model() != "NE135FBM-N41" || edidInfo.Serial() != "A1B2C3" || edidInfo.Year() != 2020 {
+		t.Errorf("unexpected edid %+v", *edidInfo)
+	}
+
+	width, height = edidInfo.Size()
+	if width != 34 || height != 19 {
+		t.Errorf("expected %dx%d cm, got %dx%d cm", 34, 19, width, height)
+	}
+
+	width, height = edidInfo.PreferredResolution()
+	if width != 1920 || height != 1080 || edidInfo.PreferredPixelClock() != 138490000 {
+		t.Errorf("expected %dx%d, got %dx%d at %d Hz", 1920, 1080, width, height, edidInfo.PreferredPixelClock())
+	}
+
+	buf[20]++
+
+	_, err = ParseEDID(buf)
+	if err == nil {
+		t.Error("expected checksum error")
+	}
+
+	_, err = ParseEDID(buf[:64])
+	if err == nil {
+		t.Error("expected truncation error")
+	}
+}
@@ -2,6 +2,7 @@ module github.com/andrieee44/sstat
 
 go 1.24.1
 
-require github.com/fsnotify/fsnotify v1.9.0
-
-require golang.org/x/sys v0.13.0 // indirect
+require (
+	github.com/fsnotify/fsnotify v1.9.0
+	golang.org/x/sys v0.13.0
+)
@@ -0,0 +1,364 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// OSReleasePath is the path to the file where operating
+// system identification data is stored.
+const OSReleasePath string = "/etc/os-release"
+
+// OSReleaseFallbackPath is the path read when [OSReleasePath] does not exist.
+const OSReleaseFallbackPath string = "/usr/lib/os-release"
+
+// MachineIdPath is the path to the file where the
+// unique machine ID of the local system is stored.
+const MachineIdPath string = "/etc/machine-id"
+
+// BootIdPath is the path to the file where the
+// random ID of the current boot is stored.
+const BootIdPath string = "/proc/sys/kernel/random/boot_id"
+
+// DMIPath is the directory where the DMI (SMBIOS)
+// information of the system is located.
+const DMIPath string = "/sys/class/dmi/id"
+
+// HostInfo reports the identity of the host. Documentation for the
+// object methods are taken from [uname(2)], [os-release(5)],
+// [machine-id(5)] and [sysfs-class-dmi-id].
+//
+// [uname(2)]: https://man.archlinux.org/man/uname.2.en
+// [os-release(5)]: https://man.archlinux.org/man/os-release.5.en
+// [machine-id(5)]: https://man.archlinux.org/man/machine-id.5.en
+// [sysfs-class-dmi-id]: https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-dmi-id
+type HostInfo struct {
+	sysname   string
+	nodename  string
+	release   string
+	version   string
+	machine   string
+	osRelease map[string]string
+	machineId string
+	bootId    string
+	dmi       map[string]string
+}
+
+// Sysname reports the operating system name, e.g. "Linux".
+func (info *HostInfo) Sysname() (value string) {
+	return info.sysname
+}
+
+// Nodename reports the name of the host within the
+// communications network it is attached to.
+func (info *HostInfo) Nodename() (value string) {
+	return info.nodename
+}
+
+// Release reports the kernel release, e.g. "6.1.0-13-amd64".
+func (info *HostInfo) Release() (value string) {
+	return info.release
+}
+
+// Version reports the kernel version, e.g. "#1 SMP PREEMPT_DYNAMIC".
+func (info *HostInfo) Version() (value string) {
+	return info.version
+}
+
+// Machine reports the hardware identifier, e.g. "x86_64".
+func (info *HostInfo) Machine() (value string) {
+	return info.machine
+}
+
+// OSRelease reports the value of the specified key in [OSReleasePath]
+// and whether if the key is valid or not.
+func (info *HostInfo) OSRelease(key string) (value string, ok bool) {
+	value, ok = info.osRelease[key]
+
+	return value, ok
+}
+
+// Name reports a string identifying the operating system,
+// without a version component, e.g. "Fedora Linux".
+func (info *HostInfo) Name() (value string, ok bool) {
+	return info.OSRelease("NAME")
+}
+
+// Id reports a lower-case string identifying the operating system,
+// excluding any version information, e.g. "fedora".
+func (info *HostInfo) Id() (value string, ok bool) {
+	return info.OSRelease("ID")
+}
+
+// IdLike reports the operating system identifiers of
+// operating systems that are closely related to the local
+// operating system, e.g. ["rhel", "fedora"].
+func (info *HostInfo) IdLike() (value []string, ok bool) {
+	var str string
+
+	str, ok = info.OSRelease("ID_LIKE")
+
+	return strings.Fields(str), ok
+}
+
+// VersionId reports a lower-case string identifying the
+// operating system version, excluding any name information, e.g. "17".
+func (info *HostInfo) VersionId() (value string, ok bool) {
+	return info.OSRelease("VERSION_ID")
+}
+
+// PrettyName reports a pretty operating system name in a format
+// suitable for presentation to the user.
+func (info *HostInfo) PrettyName() (value string, ok bool) {
+	return info.OSRelease("PRETTY_NAME")
+}
+
+// MachineId reports the unique machine ID of the local system
+// as 32 lower-case hexadecimal characters. It is blank if
+// [MachineIdPath] does not exist.
+func (info *HostInfo) MachineId() (value string) {
+	return info.machineId
+}
+
+// BootId reports the random ID generated on each boot.
+func (info *HostInfo) BootId() (value string) {
+	return info.bootId
+}
+
+// DMI reports the value of the specified file in [DMIPath]
+// and whether if the file could be read or not. Some files,
+// such as "product_serial", are only readable by root.
+func (info *HostInfo) DMI(key string) (value string, ok bool) {
+	value, ok = info.dmi[key]
+
+	return value, ok
+}
+
+// SysVendor reports the vendor of the system.
+func (info *HostInfo) SysVendor() (value string, ok bool) {
+	return info.DMI("sys_vendor")
+}
+
+// ProductName reports the product name of the system.
+func (info *HostInfo) ProductName() (value string, ok bool) {
+	return info.DMI("product_name")
+}
+
+// ProductVersion reports the product version of the system.
+func (info *HostInfo) ProductVersion() (value string, ok bool) {
+	return info.DMI("product_version")
+}
+
+// BoardVendor reports the vendor of the mainboard.
+func (info *HostInfo) BoardVendor() (value string, ok bool) {
+	return info.DMI("board_vendor")
+}
+
+// BoardName reports the name of the mainboard.
+func (info *HostInfo) BoardName() (value string, ok bool) {
+	return info.DMI("board_name")
+}
+
+// BiosVendor reports the vendor of the BIOS.
+func (info *HostInfo) BiosVendor() (value string, ok bool) {
+	return info.DMI("bios_vendor")
+}
+
+// BiosVersion reports the version of the BIOS.
+func (info *HostInfo) BiosVersion() (value string, ok bool) {
+	return info.DMI("bios_version")
+}
+
+// BiosDate reports the release date of the BIOS.
+func (info *HostInfo) BiosDate() (value string, ok bool) {
+	return info.DMI("bios_date")
+}
+
+// unquoteOSRelease removes shell-style quoting from an
+// os-release value as described in [os-release(5)].
+func unquoteOSRelease(str string) (string, error) {
+	var (
+		builder strings.Builder
+		quote   byte
+		idx     int
+	)
+
+	for idx = 0; idx < len(str); idx++ {
+		switch {
+		case quote == 0 && (str[idx] == '"' || str[idx] == '\''):
+			quote = str[idx]
+		case quote != 0 && str[idx] == quote:
+			quote = 0
+		case quote != '\'' && str[idx] == '\\':
+			idx++
+			if idx == len(str) {
+				return "", errors.New("trailing backslash")
+			}
+
+			if quote == '"' && !strings.ContainsRune("$\"\\`", rune(str[idx])) {
+				builder.WriteByte('\\')
+			}
+
+			builder.WriteByte(str[idx])
+		default:
+			builder.WriteByte(str[idx])
+		}
+	}
+
+	if quote != 0 {
+		return "", errors.New("unterminated quote")
+	}
+
+	return builder.String(), nil
+}
+
+func osRelease(paths ...string) (map[string]string, error) {
+	var (
+		info map[string]string
+		path string
+		err  error
+	)
+
+	info = make(map[string]string)
+
+	for _, path = range paths {
+		err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+			var (
+				key, value string
+				ok         bool
+				err        error
+			)
+
+			text = strings.TrimSpace(text)
+			if text == "" || text[0] == '#' {
+				return true, nil
+			}
+
+			key, value, ok = strings.Cut(text, "=")
+			if !ok {
+				return false, fmt.Errorf("%s: invalid os-release format", path)
+			}
+
+			info[key], err = unquoteOSRelease(value)
+			if err != nil {
+				return false, fmt.Errorf("%s: %s: %w", path, key, err)
+			}
+
+			return true, nil
+		})
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		return info, err
+	}
+
+	return info, nil
+}
+
+func dmi(dir string) (map[string]string, error) {
+	var (
+		info    map[string]string
+		entries []os.DirEntry
+		value   string
+		idx     int
+		err     error
+	)
+
+	info = make(map[string]string)
+
+	entries, err = os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return info, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx = range entries {
+		if !entries[idx].Type().IsRegular() {
+			continue
+		}
+
+		value, err = PathReadStr(filepath.Join(dir, entries[idx].Name()))
+		if errors.Is(err, fs.ErrPermission) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		info[entries[idx].Name()] = value
+	}
+
+	return info, nil
+}
+
+func pathReadOptionalStr(path string) (string, error) {
+	var (
+		str string
+		err error
+	)
+
+	str, err = PathReadStr(path)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return "", nil
+	}
+
+	return str, err
+}
+
+// Host returns the identity of the host from uname(2), [OSReleasePath]
+// (or [OSReleaseFallbackPath]), [MachineIdPath], [BootIdPath] and [DMIPath].
+// Files that do not exist or require root are left blank.
+func Host() (*HostInfo, error) {
+	var (
+		hostInfo *HostInfo
+		utsname  unix.Utsname
+		err      error
+	)
+
+	err = unix.Uname(&utsname)
+	if err != nil {
+		return nil, err
+	}
+
+	hostInfo = &HostInfo{
+		sysname:  unix.ByteSliceToString(utsname.Sysname[:]),
+		nodename: unix.ByteSliceToString(utsname.Nodename[:]),
+		release:  unix.ByteSliceToString(utsname.Release[:]),
+		version:  unix.ByteSliceToString(utsname.Version[:]),
+		machine:  unix.ByteSliceToString(utsname.Machine[:]),
+	}
+
+	hostInfo.osRelease, err = osRelease(OSReleasePath, OSReleaseFallbackPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostInfo.machineId, err = pathReadOptionalStr(MachineIdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostInfo.bootId, err = pathReadOptionalStr(BootIdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostInfo.dmi, err = dmi(DMIPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return hostInfo, nil
+}
@@ -0,0 +1,25 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the operating system, kernel and hardware of the host.
+func ExampleHost() {
+	var (
+		hostInfo                *sstat.HostInfo
+		prettyName, productName string
+		err                     error
+	)
+
+	hostInfo, err = sstat.Host()
+	if err != nil {
+		panic(err)
+	}
+
+	prettyName, _ = hostInfo.PrettyName()
+	productName, _ = hostInfo.ProductName()
+	fmt.Printf("%s %s %s on %s\n", prettyName, hostInfo.Release(), hostInfo.Machine(), productName)
+}
@@ -0,0 +1,140 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestUnquoteOSRelease(t *testing.T) {
+	var (
+		tests    map[string]string
+		in, want string
+		value    string
+		err      error
+	)
+
+	tests = map[string]string{
+		`fedora`:                          "fedora",
+		`"Fedora Linux"`:                  "Fedora Linux",
+		`'Arch Linux'`:                    "Arch Linux",
+		`"say \"hi\" \$HOME \\ \n"`:       `say "hi" $HOME \ \n`,
+		`'single \"quotes\" are literal'`: `single \"quotes\" are literal`,
+		`Debian\ GNU/Linux`:               "Debian GNU/Linux",
+		`"rhel centos"`:                   "rhel centos",
+	}
+
+	for in, want = range tests {
+		value, err = unquoteOSRelease(in)
+		tErrorIf(t, err)
+
+		if value != want {
+			t.Errorf("%s: expected %q, got %q", in, want, value)
+		}
+	}
+
+	_, err = unquoteOSRelease(`"unterminated`)
+	if err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestOSRelease(t *testing.T) {
+	var (
+		root      string
+		hostInfo  *HostInfo
+		idLike    []string
+		versionId string
+		err       error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"usr/lib/os-release": "# comment\n\nNAME=\"Rocky Linux\"\nID=rocky\nID_LIKE=\"rhel centos fedora\"\nVERSION_ID='9.3'\nPRETTY_NAME=\"Rocky Linux 9.3 (Blue Onyx)\"\n",
+	})
+
+	hostInfo = new(HostInfo)
+
+	hostInfo.osRelease, err = osRelease(filepath.Join(root, "etc", "os-release"), filepath.Join(root, "usr", "lib", "os-release"))
+	tErrorIf(t, err)
+
+	idLike, _ = hostInfo.IdLike()
+	if !slices.Equal(idLike, []string{"rhel", "centos", "fedora"}) {
+		t.Errorf("unexpected ID_LIKE %v", idLike)
+	}
+
+	versionId, _ = hostInfo.VersionId()
+	if versionId != "9.3" {
+		t.Errorf("expected %q, got %q", "9.3", versionId)
+	}
+}
+
+func TestDMI(t *testing.T) {
+	var (
+		root, biosVersion string
+		hostInfo          *HostInfo
+		ok                bool
+		err               error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"sys_vendor":     "LENOVO\n",
+		"product_name":   "20XW0055US\n",
+		"bios_version":   "N32ET86W (1.62 )\n",
+		"product_serial": "PF2XXXXX\n",
+	})
+
+	tErrorIf(t, os.Chmod(filepath.Join(root, "product_serial"), 0))
+
+	hostInfo = new(HostInfo)
+
+	hostInfo.dmi, err = dmi(root)
+	tErrorIf(t, err)
+
+	biosVersion, _ = hostInfo.BiosVersion()
+	if biosVersion != "N32ET86W (1.62 )" {
+		t.Errorf("expected %q, got %q", "N32ET86W (1.62 )", biosVersion)
+	}
+
+	_, ok = hostInfo.DMI("product_serial")
+	if ok && os.Geteuid() != 0 {
+		t.Error("expected product_serial to be unreadable")
+	}
+
+	hostInfo.dmi, err = dmi(filepath.Join(root, "missing"))
+	tErrorIf(t, err)
+}
+
+func TestPathReadOptionalStr(t *testing.T) {
+	var (
+		root, path, value string
+		err               error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"etc/machine-id": "",
+	})
+
+	for _, path = range []string{"etc/machine-id", "etc/missing"} {
+		value, err = pathReadOptionalStr(filepath.Join(root, path))
+		tErrorIf(t, err)
+
+		if value != "" {
+			t.Errorf("%s: expected %q, got %q", path, "", value)
+		}
+	}
+}
+
+func TestHost(t *testing.T) {
+	var (
+		hostInfo *HostInfo
+		err      error
+	)
+
+	hostInfo, err = Host()
+	tErrorIf(t, err)
+
+	if hostInfo.Sysname() != "Linux" {
+		t.Errorf("expected %q, got %q", "Linux", hostInfo.Sysname())
+	}
+}
@@ -0,0 +1,368 @@
+package sstat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// HugePagesPath is the directory where the information for
+// every supported huge page size is located.
+const HugePagesPath string = "/sys/kernel/mm/hugepages"
+
+// NodePath is the directory where the information for
+// NUMA nodes are located.
+const NodePath string = "/sys/devices/system/node"
+
+// TransparentHugePagePath is the directory where the
+// transparent huge page settings are located.
+const TransparentHugePagePath string = "/sys/kernel/mm/transparent_hugepage"
+
+// HugePageInfo reports the huge page pool of a single huge page size.
+// Documentation for the object methods are taken from [sysfs-kernel-mm-hugepages].
+//
+// [sysfs-kernel-mm-hugepages]: https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-kernel-mm-hugepages
+type HugePageInfo struct {
+	size                  int
+	node                  int
+	nrHugepages           int
+	freeHugepages         int
+	resvHugepages         int
+	surplusHugepages      int
+	nrOvercommitHugepages int
+}
+
+// Size reports the size of the huge pages in kilobytes.
+func (info *HugePageInfo) Size() (value int) {
+	return info.size
+}
+
+// Node reports the NUMA node of the huge page pool.
+// The value is -1 for the system-wide pool.
+func (info *HugePageInfo) Node() (value int) {
+	return info.node
+}
+
+// NrHugepages reports the number of persistent huge pages in the pool.
+func (info *HugePageInfo) NrHugepages() (value int) {
+	return info.nrHugepages
+}
+
+// FreeHugepages reports the number of huge pages in the pool
+// that are not yet allocated.
+func (info *HugePageInfo) FreeHugepages() (value int) {
+	return info.freeHugepages
+}
+
+// ResvHugepages reports the number of huge pages for which a
+// commitment to allocate from the pool has been made,
+// but no allocation has yet been made.
+//
+// The value is always 0 for per-node pools.
+func (info *HugePageInfo) ResvHugepages() (value int) {
+	return info.resvHugepages
+}
+
+// SurplusHugepages reports the number of huge pages in the pool
+// above [HugePageInfo.NrHugepages].
+func (info *HugePageInfo) SurplusHugepages() (value int) {
+	return info.surplusHugepages
+}
+
+// NrOvercommitHugepages reports the maximum number of surplus
+// huge pages.
+//
+// The value is always 0 for per-node pools.
+func (info *HugePageInfo) NrOvercommitHugepages() (value int) {
+	return info.nrOvercommitHugepages
+}
+
+func (info *HugePageInfo) mapIntPtrs() map[string]*int {
+	var intPtrs map[string]*int
+
+	intPtrs = map[string]*int{
+		"nr_hugepages":      &info.nrHugepages,
+		"free_hugepages":    &info.freeHugepages,
+		"surplus_hugepages": &info.surplusHugepages,
+	}
+
+	if info.node == -1 {
+		intPtrs["resv_hugepages"] = &info.resvHugepages
+		intPtrs["nr_overcommit_hugepages"] = &info.nrOvercommitHugepages
+	}
+
+	return intPtrs
+}
+
+func hugePage(path string, node int) (*HugePageInfo, error) {
+	var (
+		hugePageInfo *HugePageInfo
+		sizeStr      string
+		key          string
+		value        *int
+		ok           bool
+		err          error
+	)
+
+	sizeStr, ok = strings.CutPrefix(filepath.Base(path), "hugepages-")
+	if !ok {
+		return nil, fmt.Errorf("%s: invalid huge page directory", path)
+	}
+
+	sizeStr, ok = strings.CutSuffix(sizeStr, "kB")
+	if !ok {
+		return nil, fmt.Errorf("%s: invalid huge page directory", path)
+	}
+
+	hugePageInfo = &HugePageInfo{
+		node: node,
+	}
+
+	hugePageInfo.size, err = strconv.Atoi(sizeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value = range hugePageInfo.mapIntPtrs() {
+		*value, err = PathReadInt(filepath.Join(path, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hugePageInfo, nil
+}
+
+func hugePages(dir string, node int) ([]*HugePageInfo, error) {
+	var (
+		hugePagePaths []string
+		hugePageInfos []*HugePageInfo
+		idx           int
+		err           error
+	)
+
+	hugePagePaths, err = filepath.Glob(filepath.Join(dir, "hugepages-*kB"))
+	if err != nil {
+		return nil, err
+	}
+
+	hugePageInfos = make([]*HugePageInfo, len(hugePagePaths))
+
+	for idx = range hugePagePaths {
+		hugePageInfos[idx], err = hugePage(hugePagePaths[idx], node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hugePageInfos, nil
+}
+
+func nodeHugePages(dir, glob string) ([]*HugePageInfo, error) {
+	var (
+		hugePageInfos, nodeInfos []*HugePageInfo
+		nodePaths                []string
+		path, nodeStr            string
+		node                     int
+		ok                       bool
+		err                      error
+	)
+
+	nodePaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path = range nodePaths {
+		nodeStr, ok = strings.CutPrefix(filepath.Base(path), "node")
+		if !ok {
+			continue
+		}
+
+		node, err = strconv.Atoi(nodeStr)
+		if err != nil {
+			continue
+		}
+
+		nodeInfos, err = hugePages(filepath.Join(path, "hugepages"), node)
+		if err != nil {
+			return nil, err
+		}
+
+		hugePageInfos = append(hugePageInfos, nodeInfos...)
+	}
+
+	return hugePageInfos, nil
+}
+
+// HugePages returns the system-wide huge page pools of
+// every huge page size in [HugePagesPath].
+func HugePages() ([]*HugePageInfo, error) {
+	return hugePages(HugePagesPath, -1)
+}
+
+// NodeHugePages returns the huge page pools of every huge page size
+// for each NUMA node found in [NodePath] + glob.
+func NodeHugePages(glob string) ([]*HugePageInfo, error) {
+	return nodeHugePages(NodePath, glob)
+}
+
+// TransparentHugePageInfo reports transparent huge page settings and
+// khugepaged statistics. Documentation for the object methods are taken
+// from [transhuge].
+//
+// [transhuge]: https://www.kernel.org/doc/Documentation/admin-guide/mm/transhuge.rst
+type TransparentHugePageInfo struct {
+	enabled      string
+	defrag       string
+	shmemEnabled string
+	useZeroPage  int
+	hpagePmdSize int
+	khugepaged   map[string]int
+}
+
+// Enabled reports the active transparent huge page mode.
+//
+// Valid values are:
+//   - "always"
+//   - "madvise"
+//   - "never"
+func (info *TransparentHugePageInfo) Enabled() (value string) {
+	return info.enabled
+}
+
+// Defrag reports whether the kernel should use direct compaction
+// to make huge pages available.
+//
+// Valid values are:
+//   - "always"
+//   - "defer"
+//   - "defer+madvise"
+//   - "madvise"
+//   - "never"
+func (info *TransparentHugePageInfo) Defrag() (value string) {
+	return info.defrag
+}
+
+// ShmemEnabled reports the huge page allocation policy for
+// internal shmem mounts.
+//
+// Valid values are:
+//   - "always"
+//   - "within_size"
+//   - "advise"
+//   - "never"
+//   - "deny"
+//   - "force"
+func (info *TransparentHugePageInfo) ShmemEnabled() (value string) {
+	return info.shmemEnabled
+}
+
+// UseZeroPage reports whether the huge zero page is used
+// for read page faults.
+func (info *TransparentHugePageInfo) UseZeroPage() (value int) {
+	return info.useZeroPage
+}
+
+// HpagePmdSize reports the size of a PMD mapped huge page in bytes.
+func (info *TransparentHugePageInfo) HpagePmdSize() (value int) {
+	return info.hpagePmdSize
+}
+
+// Khugepaged reports the value of the specified file in
+// [TransparentHugePagePath]/khugepaged and whether if the key
+// is valid or not.
+func (info *TransparentHugePageInfo) Khugepaged(key string) (value int, ok bool) {
+	value, ok = info.khugepaged[key]
+
+	return value, ok
+}
+
+// PagesToScan reports how many pages khugepaged scans at each pass.
+func (info *TransparentHugePageInfo) PagesToScan() (value int, ok bool) {
+	return info.Khugepaged("pages_to_scan")
+}
+
+// ScanSleepMillisecs reports how many milliseconds khugepaged
+// waits between each pass.
+func (info *TransparentHugePageInfo) ScanSleepMillisecs() (value int, ok bool) {
+	return info.Khugepaged("scan_sleep_millisecs")
+}
+
+// AllocSleepMillisecs reports how many milliseconds khugepaged waits
+// when there is a huge page allocation failure.
+func (info *TransparentHugePageInfo) AllocSleepMillisecs() (value int, ok bool) {
+	return info.Khugepaged("alloc_sleep_millisecs")
+}
+
+// PagesCollapsed reports the number of huge pages collapsed by khugepaged.
+func (info *TransparentHugePageInfo) PagesCollapsed() (value int, ok bool) {
+	return info.Khugepaged("pages_collapsed")
+}
+
+// FullScans reports the number of complete passes khugepaged
+// has made over the address space.
+func (info *TransparentHugePageInfo) FullScans() (value int, ok bool) {
+	return info.Khugepaged("full_scans")
+}
+
+func transparentHugePage(dir string) (*TransparentHugePageInfo, error) {
+	var (
+		thpInfo *TransparentHugePageInfo
+		entries []os.DirEntry
+		key     string
+		value   *string
+		str     string
+		idx     int
+		err     error
+	)
+
+	thpInfo = &TransparentHugePageInfo{
+		khugepaged: make(map[string]int),
+	}
+
+	for key, value = range map[string]*string{
+		"enabled":       &thpInfo.enabled,
+		"defrag":        &thpInfo.defrag,
+		"shmem_enabled": &thpInfo.shmemEnabled,
+	} {
+		str, err = PathReadStr(filepath.Join(dir, key))
+		if err != nil {
+			return nil, err
+		}
+
+		*value = activeBracket(str)
+	}
+
+	thpInfo.useZeroPage, err = PathReadInt(filepath.Join(dir, "use_zero_page"))
+	if err != nil {
+		return nil, err
+	}
+
+	thpInfo.hpagePmdSize, err = PathReadInt(filepath.Join(dir, "hpage_pmd_size"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err = os.ReadDir(filepath.Join(dir, "khugepaged"))
+	if err != nil {
+		return nil, err
+	}
+
+	for idx = range entries {
+		thpInfo.khugepaged[entries[idx].Name()], err = PathReadInt(filepath.Join(dir, "khugepaged", entries[idx].Name()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return thpInfo, nil
+}
+
+// TransparentHugePage returns transparent huge page settings and
+// khugepaged statistics from [TransparentHugePagePath].
+func TransparentHugePage() (*TransparentHugePageInfo, error) {
+	return transparentHugePage(TransparentHugePagePath)
+}
@@ -0,0 +1,40 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the free huge pages of each huge page size.
+func ExampleHugePages() {
+	var (
+		hugePageInfos []*sstat.HugePageInfo
+		idx           int
+		err           error
+	)
+
+	hugePageInfos, err = sstat.HugePages()
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range hugePageInfos {
+		fmt.Printf("%dkB: %d/%d free\n", hugePageInfos[idx].Size(), hugePageInfos[idx].FreeHugepages(), hugePageInfos[idx].NrHugepages())
+	}
+}
+
+// Print the transparent huge page mode.
+func ExampleTransparentHugePage() {
+	var (
+		thpInfo *sstat.TransparentHugePageInfo
+		err     error
+	)
+
+	thpInfo, err = sstat.TransparentHugePage()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("THP:", thpInfo.Enabled())
+}
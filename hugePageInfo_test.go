@@ -0,0 +1,108 @@
+package sstat
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHugePages(t *testing.T) {
+	var (
+		root          string
+		hugePageInfos []*HugePageInfo
+		err           error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"hugepages-2048kB/nr_hugepages":            "16\n",
+		"hugepages-2048kB/free_hugepages":          "12\n",
+		"hugepages-2048kB/resv_hugepages":          "2\n",
+		"hugepages-2048kB/surplus_hugepages":       "1\n",
+		"hugepages-2048kB/nr_overcommit_hugepages": "4\n",
+	})
+
+	hugePageInfos, err = hugePages(root, -1)
+	tErrorIf(t, err)
+
+	if len(hugePageInfos) != 1 {
+		t.Fatalf("expected %d huge page pools, got %d", 1, len(hugePageInfos))
+	}
+
+	if hugePageInfos[0].Size() != 2048 || hugePageInfos[0].FreeHugepages() != 12 || hugePageInfos[0].NrOvercommitHugepages() != 4 {
+		t.Errorf("unexpected huge page pool %+v", *hugePageInfos[0])
+	}
+
+	if !checkPath(t, HugePagesPath) {
+		return
+	}
+
+	_, err = HugePages()
+	tErrorIf(t, err)
+}
+
+func TestNodeHugePages(t *testing.T) {
+	var (
+		root          string
+		hugePageInfos []*HugePageInfo
+		err           error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"node1/hugepages/hugepages-1048576kB/nr_hugepages":      "2\n",
+		"node1/hugepages/hugepages-1048576kB/free_hugepages":    "1\n",
+		"node1/hugepages/hugepages-1048576kB/surplus_hugepages": "0\n",
+		"possible": "0-1\n",
+	})
+
+	hugePageInfos, err = nodeHugePages(root, "node*")
+	tErrorIf(t, err)
+
+	if len(hugePageInfos) != 1 || hugePageInfos[0].Node() != 1 || hugePageInfos[0].NrHugepages() != 2 {
+		t.Errorf("unexpected node huge page pools %v", hugePageInfos)
+	}
+
+	if !checkPath(t, filepath.Join(NodePath, "node0", "hugepages")) {
+		return
+	}
+
+	_, err = NodeHugePages("node*")
+	tErrorIf(t, err)
+}
+
+func TestTransparentHugePage(t *testing.T) {
+	var (
+		root    string
+		thpInfo *TransparentHugePageInfo
+		value   int
+		ok      bool
+		err     error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"enabled":                    "always [madvise] never\n",
+		"defrag":                     "always defer defer+madvise [madvise] never\n",
+		"shmem_enabled":              "always within_size advise [never] deny force\n",
+		"use_zero_page":              "1\n",
+		"hpage_pmd_size":             "2097152\n",
+		"khugepaged/pages_collapsed": "37\n",
+		"khugepaged/full_scans":      "5\n",
+	})
+
+	thpInfo, err = transparentHugePage(root)
+	tErrorIf(t, err)
+
+	if thpInfo.Enabled() != "madvise" || thpInfo.ShmemEnabled() != "never" || thpInfo.HpagePmdSize() != 2097152 {
+		t.Errorf("unexpected transparent huge page settings %+v", *thpInfo)
+	}
+
+	value, ok = thpInfo.PagesCollapsed()
+	if !ok || value != 37 {
+		t.Errorf("expected %d, got %d", 37, value)
+	}
+
+	if !checkPath(t, TransparentHugePagePath) {
+		return
+	}
+
+	_, err = TransparentHugePage()
+	tErrorIf(t, err)
+}
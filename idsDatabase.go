@@ -0,0 +1,174 @@
+package sstat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PCIIdsPath is the usual path of the pci.ids database
+// shipped by the hwdata package.
+const PCIIdsPath string = "/usr/share/hwdata/pci.ids"
+
+// USBIdsPath is the usual path of the usb.ids database
+// shipped by the hwdata package.
+const USBIdsPath string = "/usr/share/hwdata/usb.ids"
+
+// IdsDatabase resolves vendor, device and class ids to names using a
+// database in the format of [pci.ids] or [usb.ids].
+//
+// [pci.ids]: https://pci-ids.ucw.cz
+// [usb.ids]: http://www.linux-usb.org/usb-ids.html
+type IdsDatabase struct {
+	vendors    map[int]string
+	devices    map[[2]int]string
+	classes    map[int]string
+	subclasses map[[2]int]string
+}
+
+// Vendor reports the name of vendor and whether
+// if the vendor is in the database or not.
+func (db *IdsDatabase) Vendor(vendor int) (value string, ok bool) {
+	value, ok = db.vendors[vendor]
+
+	return value, ok
+}
+
+// Device reports the name of the device of vendor and
+// whether if the device is in the database or not.
+func (db *IdsDatabase) Device(vendor, device int) (value string, ok bool) {
+	value, ok = db.devices[[2]int{vendor, device}]
+
+	return value, ok
+}
+
+// Class reports the name of the base class and
+// whether if the class is in the database or not.
+func (db *IdsDatabase) Class(class int) (value string, ok bool) {
+	value, ok = db.classes[class]
+
+	return value, ok
+}
+
+// Subclass reports the name of the subclass of the base class and
+// whether if the subclass is in the database or not.
+func (db *IdsDatabase) Subclass(class, subclass int) (value string, ok bool) {
+	value, ok = db.subclasses[[2]int{class, subclass}]
+
+	return value, ok
+}
+
+func parseIdsLine(line string, digits int) (int, string, error) {
+	var (
+		id, name string
+		num      int64
+		ok       bool
+		err      error
+	)
+
+	id, name, ok = strings.Cut(line, "  ")
+	if !ok || len(id) != digits {
+		return 0, "", fmt.Errorf("%s: invalid ids line", line)
+	}
+
+	num, err = strconv.ParseInt(id, 16, 0)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return int(num), strings.TrimSpace(name), nil
+}
+
+// ParseIds decodes a database in the format of pci.ids or usb.ids.
+// Only vendors, devices, classes and subclasses are kept.
+// Other sections of usb.ids, such as HID usages, are skipped.
+func ParseIds(reader io.Reader) (*IdsDatabase, error) {
+	var (
+		db      *IdsDatabase
+		scanner *bufio.Scanner
+		line    string
+		depth   int
+		section string
+		parent  int
+		id      int
+		name    string
+		err     error
+	)
+
+	db = &IdsDatabase{
+		vendors:    make(map[int]string),
+		devices:    make(map[[2]int]string),
+		classes:    make(map[int]string),
+		subclasses: make(map[[2]int]string),
+	}
+
+	scanner = bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line = scanner.Text()
+
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		depth = len(line) - len(strings.TrimLeft(line, "\t"))
+		line = line[depth:]
+
+		switch {
+		case depth == 0 && strings.HasPrefix(line, "C "):
+			section = "class"
+			parent, name, err = parseIdsLine(line[2:], 2)
+			db.classes[parent] = name
+		case depth == 0 && len(line) > 4 && line[4] == ' ' && strings.Trim(line[:4], "0123456789abcdefABCDEF") == "":
+			section = "vendor"
+			parent, name, err = parseIdsLine(line, 4)
+			db.vendors[parent] = name
+		case depth == 0:
+			section = ""
+		case depth == 1 && section == "vendor":
+			id, name, err = parseIdsLine(line, 4)
+			db.devices[[2]int{parent, id}] = name
+		case depth == 1 && section == "class":
+			id, name, err = parseIdsLine(line, 2)
+			db.subclasses[[2]int{parent, id}] = name
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// LoadIds decodes the pci.ids or usb.ids database in path,
+// such as [PCIIdsPath] or [USBIdsPath].
+func LoadIds(path string) (*IdsDatabase, error) {
+	var (
+		file *os.File
+		db   *IdsDatabase
+		err  error
+	)
+
+	file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err = ParseIds(file)
+	if err != nil {
+		file.Close()
+
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return db, file.Close()
+}
@@ -0,0 +1,87 @@
+package sstat
+
+import (
+	"strings"
+	"testing"
+)
+
+const pciIdsFixture string = `# List of PCI ID's
+#
+8086  Intel Corporation
+	9a49  TigerLake-LP GT2 [Iris Xe Graphics]
+		1028 0a21  Iris Xe Graphics
+	a0e8  Tiger Lake-LP Serial IO I2C Controller #0
+10ec  Realtek Semiconductor Co., Ltd.
+	8168  RTL8111/8168/8211/8411 PCI Express Gigabit Ethernet Controller
+
+# List of known device classes, subclasses and programming interfaces
+C 03  Display controller
+	00  VGA compatible controller
+		00  VGA controller
+C 0c  Serial bus controller
+	05  SMBus
+`
+
+const usbIdsFixture string = `#	List of USB ID's
+046d  Logitech, Inc.
+	c077  M105 Optical Mouse
+		00  Interface
+1d6b  Linux Foundation
+	0002  2.0 root hub
+
+C 09  Hub
+	00  Unused
+HID 21  HID
+HUT 01  Generic Desktop Controls
+	000  Undefined
+`
+
+func TestParseIds(t *testing.T) {
+	var (
+		db    *IdsDatabase
+		value string
+		ok    bool
+		err   error
+	)
+
+	db, err = ParseIds(strings.NewReader(pciIdsFixture))
+	tErrorIf(t, err)
+
+	value, ok = db.Vendor(0x8086)
+	if !ok || value != "Intel Corporation" {
+		t.Errorf("unexpected vendor %q", value)
+	}
+
+	value, ok = db.Device(0x8086, 0x9a49)
+	if !ok || value != "TigerLake-LP GT2 [Iris Xe Graphics]" {
+		t.Errorf("unexpected device %q", value)
+	}
+
+	value, ok = db.Subclass(0x0c, 0x05)
+	if !ok || value != "SMBus" {
+		t.Errorf("unexpected subclass %q", value)
+	}
+
+	_, ok = db.Device(0x10ec, 0x9a49)
+	if ok {
+		t.Error("expected unknown device")
+	}
+
+	db, err = ParseIds(strings.NewReader(usbIdsFixture))
+	tErrorIf(t, err)
+
+	value, ok = db.Device(0x046d, 0xc077)
+	if !ok || value != "M105 Optical Mouse" {
+		t.Errorf("unexpected usb device %q", value)
+	}
+
+	value, ok = db.Class(0x09)
+	if !ok || value != "Hub" {
+		t.Errorf("unexpected usb class %q", value)
+	}
+
+	_, err = ParseIds(strings.NewReader("8086  Intel Corporation\n\tzzzz  Broken\n"))
+	if err == nil {
+		t.Error("expected error for invalid device id")
+	}
+}
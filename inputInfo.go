@@ -0,0 +1,400 @@
+package sstat
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// InputDevicesPath is the path to the file
+// where the input device information is located.
+const InputDevicesPath string = "/proc/bus/input/devices"
+
+// InputDevPath is the directory where the input
+// device nodes are located.
+const InputDevPath string = "/dev/input"
+
+// Bits of the capability bitmaps from linux/input-event-codes.h.
+const (
+	inputPropDirect = 0x01
+
+	evKey = 0x01
+	evRel = 0x02
+	evAbs = 0x03
+	evSw  = 0x05
+
+	keyEsc         = 0x01
+	keyS           = 0x1f
+	btnMisc        = 0x100
+	btnMouse       = 0x110
+	btnJoystick    = 0x120
+	btnDigi        = 0x140
+	btnToolPen     = 0x140
+	btnToolFinger  = 0x145
+	btnTouch       = 0x14a
+	btnStylus      = 0x14b
+	keyOk          = 0x160
+	btnTriggerHapy = 0x2c0
+
+	relX = 0x00
+	relY = 0x01
+
+	absX          = 0x00
+	absY          = 0x01
+	absMtPosition = 0x35
+)
+
+// InputDeviceInfo reports information about an input device from a block
+// of [InputDevicesPath].
+type InputDeviceInfo struct {
+	bus, vendor      int
+	product, version int
+	name             string
+	phys             string
+	sysfs            string
+	uniq             string
+	handlers         []string
+	bitmaps          map[string][]uint64
+}
+
+// Bus reports the bus type of the device, e.g. 0x0003 for USB.
+func (info *InputDeviceInfo) Bus() (value int) {
+	return info.bus
+}
+
+// Vendor reports the vendor id of the device.
+func (info *InputDeviceInfo) Vendor() (value int) {
+	return info.vendor
+}
+
+// Product reports the product id of the device.
+func (info *InputDeviceInfo) Product() (value int) {
+	return info.product
+}
+
+// Version reports the version of the device.
+func (info *InputDeviceInfo) Version() (value int) {
+	return info.version
+}
+
+// Name reports the name of the device, e.g. "AT Translated Set 2 keyboard".
+func (info *InputDeviceInfo) Name() (value string) {
+	return info.name
+}
+
+// Phys reports the physical path of the device, e.g. "isa0060/serio0/input0".
+func (info *InputDeviceInfo) Phys() (value string) {
+	return info.phys
+}
+
+// Sysfs reports the path of the device relative to /sys,
+// e.g. "/devices/platform/i8042/serio0/input/input3".
+func (info *InputDeviceInfo) Sysfs() (value string) {
+	return info.sysfs
+}
+
+// Uniq reports the unique identifier of the device, such as a serial
+// number or a MAC address. It is blank for most devices.
+func (info *InputDeviceInfo) Uniq() (value string) {
+	return info.uniq
+}
+
+// Handlers reports the input handlers bound to the
+// device, e.g. "sysrq", "kbd", "leds" or "event3".
+func (info *InputDeviceInfo) Handlers() (value []string) {
+	return info.handlers
+}
+
+// EventPaths reports the paths of the event devices
+// of the device in [InputDevPath], e.g. "/dev/input/event3".
+func (info *InputDeviceInfo) EventPaths() (value []string) {
+	var idx int
+
+	for idx = range info.handlers {
+		if strings.HasPrefix(info.handlers[idx], "event") {
+			value = append(value, filepath.Join(InputDevPath, info.handlers[idx]))
+		}
+	}
+
+	return value
+}
+
+// Capability reports whether bit is set in the capability bitmap key of
+// the device, e.g. "EV", "KEY", "ABS" or "PROP".
+func (info *InputDeviceInfo) Capability(key string, bit int) bool {
+	var bitmap []uint64
+
+	bitmap = info.bitmaps[key]
+
+	if bit < 0 || bit/64 >= len(bitmap) {
+		return false
+	}
+
+	return bitmap[bit/64]&(1<<(bit%64)) != 0
+}
+
+func (info *InputDeviceInfo) anyCapability(key string, from, to int) bool {
+	var bit int
+
+	for bit = from; bit < to; bit++ {
+		if info.Capability(key, bit) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Classes reports the classes of the device decoded from its capability
+// bitmaps, following the heuristics of udev's input_id builtin.
+//
+// Valid values are:
+//   - "keyboard"    : full keyboard with letter keys
+//   - "key"         : device with keys, such as a power button
+//   - "mouse"       : relative pointing device
+//   - "touchpad"    : indirect absolute pointing device
+//   - "touchscreen" : direct absolute pointing device
+//   - "tablet"      : absolute pointing device with a stylus
+//   - "joystick"    : joystick or gamepad
+//   - "switch"      : device with switches, such as a lid switch
+func (info *InputDeviceInfo) Classes() (value []string) {
+	var (
+		absCoords, stylus, finger, direct bool
+		bit                               int
+		keyboard                          bool
+	)
+
+	absCoords = info.Capability("EV", evAbs) &&
+		(info.Capability("ABS", absX) && info.Capability("ABS", absY) ||
+			info.Capability("ABS", absMtPosition) && info.Capability("ABS", absMtPosition+1))
+	stylus = info.Capability("KEY", btnToolPen) || info.Capability("KEY", btnStylus)
+	finger = info.Capability("KEY", btnToolFinger) && !info.Capability("KEY", btnToolPen)
+	direct = info.Capability("PROP", inputPropDirect)
+
+	if info.Capability("EV", evKey) {
+		keyboard = true
+
+		// udev's 0xFFFFFFFE mask: KEY_ESC through KEY_S.
+		for bit = keyEsc; bit <= keyS; bit++ {
+			if !info.Capability("KEY", bit) {
+				keyboard = false
+
+				break
+			}
+		}
+
+		if keyboard {
+			value = append(value, "keyboard")
+		}
+
+		if info.anyCapability("KEY", keyEsc, btnMisc) || info.anyCapability("KEY", keyOk, btnTriggerHapy) {
+			value = append(value, "key")
+		}
+	}
+
+	if info.Capability("EV", evRel) && info.Capability("REL", relX) && info.Capability("REL", relY) && info.Capability("KEY", btnMouse) {
+		value = append(value, "mouse")
+	}
+
+	if absCoords {
+		switch {
+		case stylus:
+			value = append(value, "tablet")
+		case finger && !direct:
+			value = append(value, "touchpad")
+		case info.Capability("KEY", btnTouch) || direct:
+			value = append(value, "touchscreen")
+		case info.anyCapability("KEY", btnJoystick, btnDigi):
+			value = append(value, "joystick")
+		case info.Capability("KEY", btnMouse):
+			value = append(value, "mouse")
+		}
+	}
+
+	if info.Capability("EV", evSw) {
+		value = append(value, "switch")
+	}
+
+	return value
+}
+
+// HasClass reports whether class is one of the [InputDeviceInfo.Classes]
+// of the device.
+func (info *InputDeviceInfo) HasClass(class string) bool {
+	return slices.Contains(info.Classes(), class)
+}
+
+// parseInputBitmap parses a capability bitmap printed by the kernel as
+// hexadecimal longs, most significant first. Every long but the first
+// is zero-padded to the width of a kernel long, which may differ from
+// the width of a userland uint, e.g. for 32-bit binaries on a 64-bit
+// kernel, so the bitmap is stored in 64-bit words regardless.
+func parseInputBitmap(str string) ([]uint64, error) {
+	var (
+		words    []string
+		bitmap   []uint64
+		word     uint64
+		wordBits int
+		offset   int
+		idx      int
+		err      error
+	)
+
+	words = strings.Fields(str)
+
+	wordBits = 64
+	if len(words) > 1 && !slices.ContainsFunc(words[1:], func(word string) bool { return len(word) != 8 }) {
+		wordBits = 32
+	}
+
+	bitmap = make([]uint64, (len(words)*wordBits+63)/64)
+
+	for idx = range words {
+		word, err = strconv.ParseUint(words[idx], 16, wordBits)
+		if err != nil {
+			return nil, err
+		}
+
+		offset = (len(words) - 1 - idx) * wordBits
+		bitmap[offset/64] |= word << (offset % 64)
+	}
+
+	return bitmap, nil
+}
+
+func (info *InputDeviceInfo) parseId(str string) error {
+	var (
+		field, key, value string
+		num               int64
+		ptr               *int
+		ok                bool
+		err               error
+	)
+
+	for _, field = range strings.Fields(str) {
+		key, value, ok = strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("%s: invalid input id field", field)
+		}
+
+		ptr, ok = map[string]*int{
+			"Bus":     &info.bus,
+			"Vendor":  &info.vendor,
+			"Product": &info.product,
+			"Version": &info.version,
+		}[key]
+		if !ok {
+			continue
+		}
+
+		num, err = strconv.ParseInt(value, 16, 0)
+		if err != nil {
+			return err
+		}
+
+		*ptr = int(num)
+	}
+
+	return nil
+}
+
+func (info *InputDeviceInfo) parseLine(text string) error {
+	var (
+		prefix, rest string
+		key, value   string
+		ok           bool
+		err          error
+	)
+
+	prefix, rest, ok = strings.Cut(text, ": ")
+	if !ok {
+		return fmt.Errorf("%s: invalid input device line", text)
+	}
+
+	if prefix == "I" {
+		return info.parseId(rest)
+	}
+
+	key, value, ok = strings.Cut(rest, "=")
+	if !ok {
+		return fmt.Errorf("%s: invalid input device line", text)
+	}
+
+	switch prefix {
+	case "N":
+		info.name = strings.Trim(value, `"`)
+	case "P":
+		info.phys = value
+	case "S":
+		info.sysfs = value
+	case "U":
+		info.uniq = value
+	case "H":
+		info.handlers = strings.Fields(value)
+	case "B":
+		info.bitmaps[key], err = parseInputBitmap(value)
+	}
+
+	return err
+}
+
+func inputDevices(path string) ([]*InputDeviceInfo, error) {
+	var (
+		deviceInfos []*InputDeviceInfo
+		deviceInfo  *InputDeviceInfo
+		err         error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var err error
+
+		if text == "" {
+			deviceInfo = nil
+
+			return true, nil
+		}
+
+		if deviceInfo == nil {
+			deviceInfo = &InputDeviceInfo{
+				bitmaps: make(map[string][]uint64),
+			}
+
+			deviceInfos = append(deviceInfos, deviceInfo)
+		}
+
+		err = deviceInfo.parseLine(text)
+
+		return err == nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deviceInfos, nil
+}
+
+// InputDevices returns every input device in [InputDevicesPath].
+func InputDevices() ([]*InputDeviceInfo, error) {
+	return inputDevices(InputDevicesPath)
+}
+
+// InputDevicesByClass returns every input device in [InputDevicesPath]
+// of the class class, see [InputDeviceInfo.Classes].
+func InputDevicesByClass(class string) ([]*InputDeviceInfo, error) {
+	var (
+		deviceInfos []*InputDeviceInfo
+		err         error
+	)
+
+	deviceInfos, err = InputDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(deviceInfos, func(info *InputDeviceInfo) bool {
+		return !info.HasClass(class)
+	}), nil
+}
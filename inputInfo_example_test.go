@@ -0,0 +1,29 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the event device of each attached touchscreen.
+func ExampleInputDevicesByClass() {
+	var (
+		deviceInfos []*sstat.InputDeviceInfo
+		idx         int
+		err         error
+	)
+
+	deviceInfos, err = sstat.InputDevicesByClass("touchscreen")
+	if err != nil {
+		panic(err)
+	}
+
+	if len(deviceInfos) == 0 {
+		fmt.Println("no touchscreen attached")
+	}
+
+	for idx = range deviceInfos {
+		fmt.Println(deviceInfos[idx].Name(), deviceInfos[idx].EventPaths())
+	}
+}
@@ -0,0 +1,195 @@
+package sstat
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const inputDevicesFixture string = `I: Bus=0019 Vendor=0000 Product=0005 Version=0000
+N: Name="Lid Switch"
+P: Phys=PNP0C0D/button/input0
+S: Sysfs=/devices/LNXSYSTM:00/LNXSYBUS:00/PNP0C0D:00/input/input0
+U: Uniq=
+H: Handlers=event0
+B: PROP=0
+B: EV=21
+B: SW=1
+
+I: Bus=0019 Vendor=0000 Product=0001 Version=0000
+N: Name="Power Button"
+P: Phys=LNXPWRBN/button/input0
+S: Sysfs=/devices/LNXSYSTM:00/LNXPWRBN:00/input/input2
+U: Uniq=
+H: Handlers=kbd event2
+B: PROP=0
+B: EV=3
+B: KEY=10000000000000 0
+
+I: Bus=0011 Vendor=0001 Product=0001 Version=ab83
+N: Name="AT Translated Set 2 keyboard"
+P: Phys=isa0060/serio0/input0
+S: Sysfs=/devices/platform/i8042/serio0/input/input3
+U: Uniq=
+H: Handlers=sysrq kbd leds event3
+B: PROP=0
+B: EV=120013
+B: KEY=402000000 3803078f800d001 feffffdfffefffff fffffffffffffffe
+B: MSC=10
+B: LED=7
+
+I: Bus=0018 Vendor=04f3 Product=311c Version=0100
+N: Name="ELAN0504:01 04F3:311C Touchpad"
+P: Phys=i2c-ELAN0504:01
+S: Sysfs=/devices/pci0000:00/0000:00:15.1/i2c_designware.1/i2c-2/i2c-ELAN0504:01/0018:04F3:311C.0002/input/input12
+U: Uniq=
+H: Handlers=mouse1 event12
+B: PROP=5
+B: EV=1b
+B: KEY=e520 10000 0 0 0 0
+B: ABS=2e0800000000003
+B: MSC=20
+
+I: Bus=0018 Vendor=2a94 Product=d64d Version=0100
+N: Name="ELAN9008:00 04F3:2ED7"
+P: Phys=i2c-ELAN9008:00
+S: Sysfs=/devices/pci0000:00/0000:00:15.0/i2c_designware.0/i2c-1/i2c-ELAN9008:00/0018:2A94:D64D.0001/input/input10
+U: Uniq=
+H: Handlers=mouse0 event10
+B: PROP=2
+B: EV=1b
+B: KEY=400 0 0 0 0 0
+B: ABS=3273800000000003
+B: MSC=20
+
+I: Bus=0003 Vendor=046d Product=c077 Version=0111
+N: Name="Logitech USB Optical Mouse"
+P: Phys=usb-0000:00:14.0-1/input0
+S: Sysfs=/devices/pci0000:00/0000:00:14.0/usb1/1-1/1-1:1.0/0003:046D:C077.0003/input/input14
+U: Uniq=
+H: Handlers=mouse2 event14
+B: PROP=0
+B: EV=17
+B: KEY=ff0000 0 0 0 0
+B: REL=1943
+B: MSC=10
+`
+
+func TestInputDevices(t *testing.T) {
+	var (
+		root        string
+		deviceInfos []*InputDeviceInfo
+		classes     [][]string
+		idx         int
+		err         error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"devices": inputDevicesFixture,
+	})
+
+	deviceInfos, err = inputDevices(filepath.Join(root, "devices"))
+	tErrorIf(t, err)
+
+	classes = [][]string{
+		{"switch"},
+		{"key"},
+		{"keyboard", "key"},
+		{"touchpad"},
+		{"touchscreen"},
+		{"mouse"},
+	}
+
+	if len(deviceInfos) != len(classes) {
+		t.Fatalf("expected %d devices, got %d", len(classes), len(deviceInfos))
+	}
+
+	for idx = range classes {
+		if !slices.Equal(deviceInfos[idx].Classes(), classes[idx]) {
+			t.Errorf("%s: expected classes %v, got %v", deviceInfos[idx].Name(), classes[idx], deviceInfos[idx].Classes())
+		}
+	}
+
+	if deviceInfos[2].Bus() != 0x11 || deviceInfos[2].Version() != 0xab83 || deviceInfos[2].Phys() != "isa0060/serio0/input0" {
+		t.Errorf("unexpected keyboard %+v", deviceInfos[2])
+	}
+
+	if !slices.Equal(deviceInfos[2].EventPaths(), []string{"/dev/input/event3"}) || !deviceInfos[2].Capability("LED", 0) {
+		t.Errorf("unexpected keyboard handlers %v", deviceInfos[2].Handlers())
+	}
+
+	if deviceInfos[3].Vendor() != 0x04f3 || deviceInfos[3].Product() != 0x311c || !deviceInfos[3].HasClass("touchpad") {
+		t.Errorf("unexpected touchpad %+v", deviceInfos[3])
+	}
+
+	if !checkPath(t, InputDevicesPath) {
+		return
+	}
+
+	_, err = InputDevices()
+	tErrorIf(t, err)
+}
+
+func TestInputDeviceKeyboard(t *testing.T) {
+	var (
+		tests = []struct {
+			name     string
+			key      []uint64
+			keyboard bool
+		}{
+			{"udev mask", []uint64{0xfffffffe}, true},
+			{"full keyboard", []uint64{0xffffffff}, true},
+			{"missing KEY_ESC", []uint64{0xfffffffc}, false},
+			{"missing KEY_S", []uint64{0x7ffffffe}, false},
+			{"no keys", []uint64{0}, false},
+		}
+		deviceInfo *InputDeviceInfo
+		idx        int
+	)
+
+	for idx = range tests {
+		deviceInfo = &InputDeviceInfo{
+			bitmaps: map[string][]uint64{
+				"EV":  {1 << evKey},
+				"KEY": tests[idx].key,
+			},
+		}
+
+		if deviceInfo.HasClass("keyboard") != tests[idx].keyboard {
+			t.Errorf("%s: expected keyboard %t, got %v", tests[idx].name, tests[idx].keyboard, deviceInfo.Classes())
+		}
+	}
+}
+
+func TestParseInputBitmap(t *testing.T) {
+	var (
+		tests = []struct {
+			name     string
+			str      string
+			expected []uint64
+		}{
+			{"single word", "8000", []uint64{0x8000}},
+			{"unpadded", "1 0 8000", []uint64{0x8000, 0, 1}},
+			{"64-bit kernel", "1 0000000000000000 0000000000008000", []uint64{0x8000, 0, 1}},
+			{"32-bit kernel", "1 00000000 00008000", []uint64{0x8000, 1}},
+			{"32-bit kernel odd words", "3 80000000 00000001", []uint64{0x8000000000000001, 3}},
+		}
+		bitmap []uint64
+		idx    int
+		err    error
+	)
+
+	for idx = range tests {
+		bitmap, err = parseInputBitmap(tests[idx].str)
+		tErrorIf(t, err)
+
+		if !slices.Equal(bitmap, tests[idx].expected) {
+			t.Errorf("%s: expected %#x, got %#x", tests[idx].name, tests[idx].expected, bitmap)
+		}
+	}
+
+	_, err = parseInputBitmap("xyz")
+	if err == nil {
+		t.Error("expected error for invalid bitmap")
+	}
+}
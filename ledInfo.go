@@ -0,0 +1,340 @@
+package sstat
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// LEDPath is the directory where the information for
+// LED class devices are located.
+const LEDPath string = "/sys/class/leds"
+
+// LEDInfo reports LED information, such as keyboard backlights and
+// lock key indicators. Documentation for the object methods are taken
+// from [sysfs-class-led].
+//
+// [sysfs-class-led]: https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-led
+type LEDInfo struct {
+	brightness    int
+	maxBrightness int
+	trigger       string
+	triggers      []string
+	name          string
+}
+
+// Brightness reports the brightness of the LED. Values are
+// between 0 and max_brightness. A value of 0 means the LED is off.
+func (info *LEDInfo) Brightness() (value int) {
+	return info.brightness
+}
+
+// MaxBrightness reports the maximum brightness level for the LED.
+// A value of 1 means the LED can only be turned on and off.
+func (info *LEDInfo) MaxBrightness() (value int) {
+	return info.maxBrightness
+}
+
+// On reports whether the LED is lit.
+func (info *LEDInfo) On() bool {
+	return info.brightness > 0
+}
+
+// Trigger reports the active trigger of the LED, e.g. "kbd-capslock".
+// The value "none" means no trigger is active.
+func (info *LEDInfo) Trigger() (value string) {
+	return info.trigger
+}
+
+// Triggers reports every trigger available to the LED.
+func (info *LEDInfo) Triggers() (value []string) {
+	return info.triggers
+}
+
+// Name reports the name of the LED in the
+// "devicename:color:function" format.
+func (info *LEDInfo) Name() (value string) {
+	return info.name
+}
+
+// Function reports the function part of [LEDInfo.Name],
+// e.g. "capslock", "numlock" or "kbd_backlight".
+func (info *LEDInfo) Function() (value string) {
+	return info.name[strings.LastIndexByte(info.name, ':')+1:]
+}
+
+func (info *LEDInfo) mapIntPtrs() map[string]*int {
+	return map[string]*int{
+		"brightness":     &info.brightness,
+		"max_brightness": &info.maxBrightness,
+	}
+}
+
+func (info *LEDInfo) readTrigger(path string) error {
+	var (
+		str string
+		err error
+	)
+
+	str, err = PathReadStr(filepath.Join(path, "trigger"))
+	if err != nil {
+		return err
+	}
+
+	info.trigger = activeBracket(str)
+	info.triggers = strings.Fields(strings.NewReplacer("[", "", "]", "").Replace(str))
+
+	return nil
+}
+
+func led(dir, basepath string) (*LEDInfo, error) {
+	var (
+		ledInfo *LEDInfo
+		key     string
+		value   *int
+		err     error
+	)
+
+	ledInfo = &LEDInfo{
+		name: basepath,
+	}
+
+	for key, value = range ledInfo.mapIntPtrs() {
+		*value, err = PathReadInt(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ledInfo.readTrigger(filepath.Join(dir, basepath))
+	if err != nil {
+		return nil, err
+	}
+
+	return ledInfo, nil
+}
+
+func leds(dir, glob string) ([]*LEDInfo, error) {
+	var (
+		ledPaths []string
+		ledInfos []*LEDInfo
+		idx      int
+		err      error
+	)
+
+	ledPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	ledInfos = make([]*LEDInfo, len(ledPaths))
+
+	for idx = range ledPaths {
+		ledInfos[idx], err = led(dir, filepath.Base(ledPaths[idx]))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ledInfos, nil
+}
+
+// serveLED watches the LED in dir + basepath. Sysfs only notifies
+// writes from userspace, not the changes made by the kernel itself,
+// such as lock keys toggled from the keyboard or keyboard backlights
+// changed by hardware keys. Those are notified by brightness_hw_changed
+// where the driver supports it, otherwise the brightness is polled
+// every interval, unless interval is 0.
+func serveLED(ctx context.Context, ledChan chan<- *LEDInfo, errChan chan<- error, dir, basepath string, interval time.Duration) {
+	var (
+		ledInfo, newLEDInfo *LEDInfo
+		watcher             *fsnotify.Watcher
+		ticker              *time.Ticker
+		tick                <-chan time.Time
+		event               fsnotify.Event
+		infoPath, infoName  string
+		hwChanged, polled   bool
+		err                 error
+	)
+
+	ledInfo, err = led(dir, basepath)
+	if err != nil {
+		send(ctx, errChan, err)
+
+		return
+	}
+
+	watcher, err = fsnotify.NewWatcher()
+	if err != nil {
+		send(ctx, errChan, err)
+
+		return
+	}
+
+	defer watcher.Close()
+
+	for _, infoPath = range []string{"brightness", "max_brightness", "trigger"} {
+		err = watcher.Add(filepath.Join(dir, basepath, infoPath))
+		if err != nil {
+			send(ctx, errChan, err)
+
+			return
+		}
+	}
+
+	err = watcher.Add(filepath.Join(dir, basepath, "brightness_hw_changed"))
+	hwChanged = err == nil
+
+	if !hwChanged && interval > 0 {
+		ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	if !send(ctx, ledChan, ledInfo) {
+		return
+	}
+
+	for {
+		newLEDInfo = new(LEDInfo)
+		*newLEDInfo = *ledInfo
+
+		select {
+		case <-ctx.Done():
+			return
+		case event = <-watcher.Events:
+			if !event.Has(fsnotify.Write) {
+				continue
+			}
+
+			infoName, polled = filepath.Base(event.Name), false
+			if infoName == "brightness_hw_changed" {
+				infoName, polled = "brightness", true
+			}
+		case <-tick:
+			infoName, polled = "brightness", true
+		case err = <-watcher.Errors:
+			send(ctx, errChan, err)
+
+			return
+		}
+
+		switch infoName {
+		case "trigger":
+			err = newLEDInfo.readTrigger(filepath.Join(dir, basepath))
+		default:
+			*newLEDInfo.mapIntPtrs()[infoName], err = PathReadInt(filepath.Join(dir, basepath, infoName))
+		}
+
+		if err != nil {
+			send(ctx, errChan, err)
+
+			return
+		}
+
+		if polled && newLEDInfo.brightness == ledInfo.brightness {
+			continue
+		}
+
+		if !send(ctx, ledChan, newLEDInfo) {
+			return
+		}
+
+		ledInfo = newLEDInfo
+	}
+}
+
+func watchLEDs(dir, glob string, interval time.Duration) (map[string]<-chan *LEDInfo, <-chan error, error) {
+	var (
+		ledChans map[string]<-chan *LEDInfo
+		ledChan  chan *LEDInfo
+		errChan  chan error
+		ledPaths []string
+		path     string
+		err      error
+	)
+
+	ledChans = make(map[string]<-chan *LEDInfo)
+	errChan = make(chan error)
+
+	ledPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, path = range ledPaths {
+		ledChan = make(chan *LEDInfo)
+		ledChans[filepath.Base(path)] = ledChan
+
+		go serveLED(context.Background(), ledChan, errChan, dir, filepath.Base(path), interval)
+	}
+
+	return ledChans, errChan, nil
+}
+
+// LEDChans returns a map of channels that sends
+// LED information for each LED found in [LEDPath] + glob.
+// The kernel does not notify the changes it makes itself, such as
+// lock keys toggled from the keyboard, so the brightness of LEDs
+// without brightness_hw_changed is polled every interval, such as
+// 250*time.Millisecond. An interval of 0 disables polling.
+// Every channel has a single consumer which must keep up with
+// the changes, see [LEDBroadcasters] for several consumers.
+func LEDChans(glob string, interval time.Duration) (map[string]<-chan *LEDInfo, <-chan error, error) {
+	return watchLEDs(LEDPath, glob, interval)
+}
+
+func ledBroadcaster(dir, basepath string, interval time.Duration) *Broadcaster[*LEDInfo] {
+	return watchBroadcaster(func(ctx context.Context, ledChan chan<- *LEDInfo, errChan chan<- error) {
+		serveLED(ctx, ledChan, errChan, dir, basepath, interval)
+	})
+}
+
+func ledBroadcasters(dir, glob string, interval time.Duration) (map[string]*Broadcaster[*LEDInfo], error) {
+	var (
+		broadcasters map[string]*Broadcaster[*LEDInfo]
+		ledPaths     []string
+		path         string
+		err          error
+	)
+
+	broadcasters = make(map[string]*Broadcaster[*LEDInfo])
+
+	ledPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path = range ledPaths {
+		broadcasters[filepath.Base(path)] = ledBroadcaster(dir, filepath.Base(path), interval)
+	}
+
+	return broadcasters, nil
+}
+
+// LEDBroadcasters returns a map of [Broadcaster] that publishes LED
+// information for each LED found in [LEDPath] + glob whenever it
+// changes. Any number of consumers may subscribe to each LED without
+// ever stalling its watcher. The brightness is polled every interval
+// like [LEDChans]. A broadcaster is closed with the error of its
+// watcher, see [Broadcaster.Err]. The watcher runs until
+// [Broadcaster.Stop].
+func LEDBroadcasters(glob string, interval time.Duration) (map[string]*Broadcaster[*LEDInfo], error) {
+	return ledBroadcasters(LEDPath, glob, interval)
+}
+
+// LED returns LED information in [LEDPath] + basepath.
+func LED(basepath string) (*LEDInfo, error) {
+	return led(LEDPath, basepath)
+}
+
+// LEDs returns all LED information in [LEDPath] + glob.
+// Use "*::kbd_backlight" for keyboard backlights and
+// "*::capslock" for caps lock indicators.
+func LEDs(glob string) ([]*LEDInfo, error) {
+	return leds(LEDPath, glob)
+}
@@ -0,0 +1,50 @@
+package sstat_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the keyboard backlight level of each keyboard.
+func ExampleLEDs() {
+	var (
+		ledInfos []*sstat.LEDInfo
+		idx      int
+		err      error
+	)
+
+	ledInfos, err = sstat.LEDs("*::kbd_backlight")
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range ledInfos {
+		fmt.Printf("%s: %d/%d\n", ledInfos[idx].Name(), ledInfos[idx].Brightness(), ledInfos[idx].MaxBrightness())
+	}
+}
+
+// Print the caps lock state at every change.
+func ExampleLEDChans() {
+	var (
+		ledChans map[string]<-chan *sstat.LEDInfo
+		ledInfo  *sstat.LEDInfo
+		errChan  <-chan error
+		err      error
+	)
+
+	ledChans, errChan, err = sstat.LEDChans("input3::capslock", 250*time.Millisecond)
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		select {
+		case ledInfo = <-ledChans["input3::capslock"]:
+			fmt.Println("Caps Lock:", ledInfo.On())
+		case err = <-errChan:
+			panic(err)
+		}
+	}
+}
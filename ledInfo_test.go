@@ -0,0 +1,231 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func ledTree(t *testing.T) string {
+	return tmpTree(t, map[string]string{
+		"tpacpi::kbd_backlight/brightness":     "1\n",
+		"tpacpi::kbd_backlight/max_brightness": "2\n",
+		"tpacpi::kbd_backlight/trigger":        "[none] kbd-scrolllock kbd-numlock kbd-capslock\n",
+		"input3::capslock/brightness":          "0\n",
+		"input3::capslock/max_brightness":      "1\n",
+		"input3::capslock/trigger":             "none kbd-scrolllock kbd-numlock [kbd-capslock]\n",
+	})
+}
+
+func TestLEDs(t *testing.T) {
+	var (
+		ledInfos []*LEDInfo
+		err      error
+	)
+
+	ledInfos, err = leds(ledTree(t), "*::kbd_backlight")
+	tErrorIf(t, err)
+
+	if len(ledInfos) != 1 || ledInfos[0].Function() != "kbd_backlight" || ledInfos[0].Brightness() != 1 || ledInfos[0].Trigger() != "none" {
+		t.Errorf("unexpected keyboard backlights %v", ledInfos)
+	}
+
+	ledInfos, err = leds(ledTree(t), "*::capslock")
+	tErrorIf(t, err)
+
+	if len(ledInfos) != 1 || ledInfos[0].On() || ledInfos[0].Trigger() != "kbd-capslock" || !slices.Contains(ledInfos[0].Triggers(), "kbd-numlock") {
+		t.Errorf("unexpected capslock LEDs %v", ledInfos)
+	}
+
+	if !checkPath(t, LEDPath) {
+		return
+	}
+
+	_, err = LEDs("*")
+	tErrorIf(t, err)
+}
+
+func TestLEDChans(t *testing.T) {
+	var (
+		root     string
+		ledChans map[string]<-chan *LEDInfo
+		ledInfo  *LEDInfo
+		errChan  <-chan error
+		file     *os.File
+		err      error
+	)
+
+	root = ledTree(t)
+
+	ledChans, errChan, err = watchLEDs(root, "*::capslock", 0)
+	tErrorIf(t, err)
+
+	select {
+	case ledInfo = <-ledChans["input3::capslock"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	}
+
+	if ledInfo.On() {
+		t.Error("expected capslock to be off")
+	}
+
+	file, err = os.OpenFile(filepath.Join(root, "input3::capslock", "brightness"), os.O_WRONLY, 0)
+	tErrorIf(t, err)
+
+	_, err = file.WriteString("1\n")
+	tErrorIf(t, err)
+	tErrorIf(t, file.Close())
+
+	select {
+	case ledInfo = <-ledChans["input3::capslock"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for capslock change")
+	}
+
+	if !ledInfo.On() {
+		t.Error("expected capslock to be on")
+	}
+}
+
+func TestLEDChansPoll(t *testing.T) {
+	var (
+		root     string
+		ledChans map[string]<-chan *LEDInfo
+		ledInfo  *LEDInfo
+		errChan  <-chan error
+		path     string
+		err      error
+	)
+
+	root = ledTree(t)
+
+	ledChans, errChan, err = watchLEDs(root, "*::capslock", 10*time.Millisecond)
+	tErrorIf(t, err)
+
+	select {
+	case ledInfo = <-ledChans["input3::capslock"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	}
+
+	// Replace the file instead of writing to it,
+	// so that no write is notified like in sysfs.
+	path = filepath.Join(root, "input3::capslock", "brightness")
+	tErrorIf(t, os.WriteFile(path+".new", []byte("1\n"), 0o644))
+	tErrorIf(t, os.Rename(path+".new", path))
+
+	select {
+	case ledInfo = <-ledChans["input3::capslock"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for polled capslock change")
+	}
+
+	if !ledInfo.On() {
+		t.Error("expected capslock to be on")
+	}
+}
+
+func TestLEDChansHwChanged(t *testing.T) {
+	var (
+		root     string
+		ledChans map[string]<-chan *LEDInfo
+		ledInfo  *LEDInfo
+		errChan  <-chan error
+		path     string
+		file     *os.File
+		err      error
+	)
+
+	root = ledTree(t)
+	tErrorIf(t, os.WriteFile(filepath.Join(root, "tpacpi::kbd_backlight", "brightness_hw_changed"), []byte("1\n"), 0o644))
+
+	ledChans, errChan, err = watchLEDs(root, "*::kbd_backlight", 0)
+	tErrorIf(t, err)
+
+	select {
+	case ledInfo = <-ledChans["tpacpi::kbd_backlight"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	}
+
+	path = filepath.Join(root, "tpacpi::kbd_backlight", "brightness")
+	tErrorIf(t, os.WriteFile(path+".new", []byte("2\n"), 0o644))
+	tErrorIf(t, os.Rename(path+".new", path))
+
+	file, err = os.OpenFile(filepath.Join(root, "tpacpi::kbd_backlight", "brightness_hw_changed"), os.O_WRONLY, 0)
+	tErrorIf(t, err)
+
+	_, err = file.WriteString("2\n")
+	tErrorIf(t, err)
+	tErrorIf(t, file.Close())
+
+	select {
+	case ledInfo = <-ledChans["tpacpi::kbd_backlight"]:
+	case err = <-errChan:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for hardware brightness change")
+	}
+
+	if ledInfo.Brightness() != 2 {
+		t.Errorf("expected %d, got %d", 2, ledInfo.Brightness())
+	}
+}
+
+func TestLEDBroadcasters(t *testing.T) {
+	var (
+		root         string
+		broadcasters map[string]*Broadcaster[*LEDInfo]
+		first, other <-chan *LEDInfo
+		ledInfo      *LEDInfo
+		valueChan    <-chan *LEDInfo
+		file         *os.File
+		err          error
+	)
+
+	root = ledTree(t)
+
+	broadcasters, err = ledBroadcasters(root, "*::capslock", 0)
+	tErrorIf(t, err)
+
+	first, _ = broadcasters["input3::capslock"].Subscribe(1)
+	other, _ = broadcasters["input3::capslock"].Subscribe(1)
+
+	for _, valueChan = range []<-chan *LEDInfo{first, other} {
+		select {
+		case ledInfo = <-valueChan:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for capslock")
+		}
+
+		if ledInfo.On() {
+			t.Error("expected capslock to be off")
+		}
+	}
+
+	file, err = os.OpenFile(filepath.Join(root, "input3::capslock", "brightness"), os.O_WRONLY, 0)
+	tErrorIf(t, err)
+
+	_, err = file.WriteString("1\n")
+	tErrorIf(t, err)
+	tErrorIf(t, file.Close())
+
+	select {
+	case ledInfo = <-other:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for capslock change")
+	}
+
+	if !ledInfo.On() {
+		t.Error("expected capslock to be on")
+	}
+
+	broadcasters["input3::capslock"].Stop()
+}
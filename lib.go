@@ -2,12 +2,17 @@ package sstat
 
 import (
 	"bufio"
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 // PathReadStr reads the file in located in path. The file is assumed
-// to have only one line delimited by a newline.
+// to have only one line delimited by a newline. An empty file reads
+// as an empty string.
 func PathReadStr(path string) (string, error) {
 	var (
 		buf []byte
@@ -19,7 +24,7 @@ func PathReadStr(path string) (string, error) {
 		return "", err
 	}
 
-	return string(buf[:len(buf)-1]), nil
+	return strings.TrimSuffix(string(buf), "\n"), nil
 }
 
 // PathReadInt reads the file in located in path and converts the contents of
@@ -85,3 +90,61 @@ func ScanFile(path string, split bufio.SplitFunc, parser func(text string) (ok b
 
 	return file.Close()
 }
+
+// activeBracket reports the bracketed word in a sysfs selection
+// such as "always [madvise] never". The whole string is reported
+// if no word is bracketed.
+func activeBracket(str string) string {
+	var start, end int
+
+	start = strings.IndexByte(str, '[')
+	end = strings.IndexByte(str, ']')
+	if start == -1 || end < start {
+		return str
+	}
+
+	return str[start+1 : end]
+}
+
+// pathReadHex reads the file in path as a hexadecimal
+// integer with or without the 0x prefix, such as the
+// vendor file of a PCI device.
+func pathReadHex(path string) (int, error) {
+	var (
+		str string
+		num int64
+		err error
+	)
+
+	str, err = PathReadStr(path)
+	if err != nil {
+		return 0, err
+	}
+
+	num, err = strconv.ParseInt(strings.TrimPrefix(str, "0x"), 16, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(num), nil
+}
+
+// pathDriver reports the name of the driver bound to the device in
+// path. It is blank if no driver is bound to the device.
+func pathDriver(path string) (string, error) {
+	var (
+		driverPath string
+		err        error
+	)
+
+	driverPath, err = os.Readlink(filepath.Join(path, "driver"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(driverPath), nil
+}
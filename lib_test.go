@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -47,6 +48,31 @@ func tmpFile(content string) (string, error) {
 	return file.Name(), nil
 }
 
+func tmpTree(t *testing.T, files map[string]string) string {
+	var (
+		root, path, content string
+		err                 error
+	)
+
+	root = t.TempDir()
+
+	for path, content = range files {
+		path = filepath.Join(root, path)
+
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
 func TestPathReadStr(t *testing.T) {
 	var (
 		path, value string
@@ -63,6 +89,17 @@ func TestPathReadStr(t *testing.T) {
 	if value != "hello" {
 		t.Errorf("expected %q, got %q", "hello", value)
 	}
+
+	path, err = tmpFile("")
+	tErrorIf(t, err)
+
+	value, err = PathReadStr(path)
+	tErrorIf(t, err)
+	tErrorIf(t, os.Remove(path))
+
+	if value != "" {
+		t.Errorf("expected %q, got %q", "", value)
+	}
 }
 
 func TestPathReadInt(t *testing.T) {
@@ -0,0 +1,1227 @@
+package sstat
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// The info types implement [json.Marshaler] and [json.Unmarshaler]
+// with the objects below, so that the values reported by their methods
+// survive a round trip through JSON. The field names are part of the
+// [Snapshot] schema and must not change within a [SnapshotVersion].
+
+// MarshalJSON encodes the fields of info as an object
+// keyed by the field names, e.g. "MemTotal".
+func (info *MemInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(info.info)
+}
+
+// UnmarshalJSON decodes info from the object of [MemInfo.MarshalJSON].
+func (info *MemInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &info.info)
+}
+
+// MarshalJSON encodes the uevent keys of info as an object
+// keyed by the uevent keys, e.g. "POWER_SUPPLY_NAME".
+func (info *PowerSupplyInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(info.info)
+}
+
+// UnmarshalJSON decodes info from the object of [PowerSupplyInfo.MarshalJSON].
+func (info *PowerSupplyInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &info.info)
+}
+
+// MarshalJSON encodes the fields of info as an object
+// keyed by the field names, e.g. "Rss".
+func (info *SmapsInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(info.info)
+}
+
+// UnmarshalJSON decodes info from the object of [SmapsInfo.MarshalJSON].
+func (info *SmapsInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &info.info)
+}
+
+type smapsMappingJSON struct {
+	Info     map[string]int `json:"info"`
+	Start    uint64         `json:"start"`
+	End      uint64         `json:"end"`
+	Offset   uint64         `json:"offset"`
+	Perms    string         `json:"perms"`
+	Dev      string         `json:"dev"`
+	Inode    int            `json:"inode"`
+	Pathname string         `json:"pathname"`
+	VmFlags  []string       `json:"vm_flags"`
+}
+
+// MarshalJSON encodes mapping as a JSON object.
+func (mapping *SmapsMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(smapsMappingJSON{
+		Info:     mapping.info,
+		Start:    mapping.start,
+		End:      mapping.end,
+		Offset:   mapping.offset,
+		Perms:    mapping.perms,
+		Dev:      mapping.dev,
+		Inode:    mapping.inode,
+		Pathname: mapping.pathname,
+		VmFlags:  mapping.vmFlags,
+	})
+}
+
+// UnmarshalJSON decodes mapping from the object of [SmapsMapping.MarshalJSON].
+func (mapping *SmapsMapping) UnmarshalJSON(data []byte) error {
+	var (
+		obj smapsMappingJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*mapping = SmapsMapping{
+		SmapsInfo: SmapsInfo{info: obj.Info},
+		start:     obj.Start,
+		end:       obj.End,
+		offset:    obj.Offset,
+		perms:     obj.Perms,
+		dev:       obj.Dev,
+		inode:     obj.Inode,
+		pathname:  obj.Pathname,
+		vmFlags:   obj.VmFlags,
+	}
+
+	return nil
+}
+
+type backlightInfoJSON struct {
+	BlPower          int    `json:"bl_power"`
+	Brightness       int    `json:"brightness"`
+	ActualBrightness int    `json:"actual_brightness"`
+	MaxBrightness    int    `json:"max_brightness"`
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	Connector        string `json:"connector,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *BacklightInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(backlightInfoJSON{
+		BlPower:          info.blPower,
+		Brightness:       info.brightness,
+		ActualBrightness: info.actualBrightness,
+		MaxBrightness:    info.maxBrightness,
+		Type:             info.typ,
+		Name:             info.name,
+		Connector:        info.connector,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [BacklightInfo.MarshalJSON].
+func (info *BacklightInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj backlightInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = BacklightInfo{
+		blPower:          obj.BlPower,
+		brightness:       obj.Brightness,
+		actualBrightness: obj.ActualBrightness,
+		maxBrightness:    obj.MaxBrightness,
+		typ:              obj.Type,
+		name:             obj.Name,
+		connector:        obj.Connector,
+	}
+
+	return nil
+}
+
+type ledInfoJSON struct {
+	Brightness    int      `json:"brightness"`
+	MaxBrightness int      `json:"max_brightness"`
+	Trigger       string   `json:"trigger"`
+	Triggers      []string `json:"triggers"`
+	Name          string   `json:"name"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *LEDInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ledInfoJSON{
+		Brightness:    info.brightness,
+		MaxBrightness: info.maxBrightness,
+		Trigger:       info.trigger,
+		Triggers:      info.triggers,
+		Name:          info.name,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [LEDInfo.MarshalJSON].
+func (info *LEDInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj ledInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = LEDInfo{
+		brightness:    obj.Brightness,
+		maxBrightness: obj.MaxBrightness,
+		trigger:       obj.Trigger,
+		triggers:      obj.Triggers,
+		name:          obj.Name,
+	}
+
+	return nil
+}
+
+type soundCardInfoJSON struct {
+	Index    int    `json:"index"`
+	Id       string `json:"id"`
+	Driver   string `json:"driver"`
+	Name     string `json:"name"`
+	LongName string `json:"long_name"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *SoundCardInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(soundCardInfoJSON{
+		Index:    info.index,
+		Id:       info.id,
+		Driver:   info.driver,
+		Name:     info.name,
+		LongName: info.longName,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [SoundCardInfo.MarshalJSON].
+func (info *SoundCardInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj soundCardInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = SoundCardInfo{
+		index:    obj.Index,
+		id:       obj.Id,
+		driver:   obj.Driver,
+		name:     obj.Name,
+		longName: obj.LongName,
+	}
+
+	return nil
+}
+
+type pcmSubstreamInfoJSON struct {
+	Card      int    `json:"card"`
+	Device    int    `json:"device"`
+	Playback  bool   `json:"playback"`
+	Subdevice int    `json:"subdevice"`
+	State     string `json:"state"`
+	OwnerPid  int    `json:"owner_pid"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *PCMSubstreamInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pcmSubstreamInfoJSON{
+		Card:      info.card,
+		Device:    info.device,
+		Playback:  info.playback,
+		Subdevice: info.subdevice,
+		State:     info.state,
+		OwnerPid:  info.ownerPid,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [PCMSubstreamInfo.MarshalJSON].
+func (info *PCMSubstreamInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj pcmSubstreamInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = PCMSubstreamInfo{
+		card:      obj.Card,
+		device:    obj.Device,
+		playback:  obj.Playback,
+		subdevice: obj.Subdevice,
+		state:     obj.State,
+		ownerPid:  obj.OwnerPid,
+	}
+
+	return nil
+}
+
+type mixerInfoJSON struct {
+	Name      string `json:"name"`
+	Min       int    `json:"min"`
+	Max       int    `json:"max"`
+	Volumes   []int  `json:"volumes"`
+	Switches  []bool `json:"switches"`
+	HasSwitch bool   `json:"has_switch"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *MixerInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mixerInfoJSON{
+		Name:      info.name,
+		Min:       info.min,
+		Max:       info.max,
+		Volumes:   info.volumes,
+		Switches:  info.switches,
+		HasSwitch: info.hasSwitch,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [MixerInfo.MarshalJSON].
+func (info *MixerInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj mixerInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = MixerInfo{
+		name:      obj.Name,
+		min:       obj.Min,
+		max:       obj.Max,
+		volumes:   obj.Volumes,
+		switches:  obj.Switches,
+		hasSwitch: obj.HasSwitch,
+	}
+
+	return nil
+}
+
+type idMappingJSON struct {
+	Inside  int64 `json:"inside"`
+	Outside int64 `json:"outside"`
+	Count   int64 `json:"count"`
+}
+
+// MarshalJSON encodes mapping as a JSON object.
+func (mapping *IdMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(idMappingJSON{
+		Inside:  mapping.inside,
+		Outside: mapping.outside,
+		Count:   mapping.count,
+	})
+}
+
+// UnmarshalJSON decodes mapping from the object of [IdMapping.MarshalJSON].
+func (mapping *IdMapping) UnmarshalJSON(data []byte) error {
+	var (
+		obj idMappingJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*mapping = IdMapping{
+		inside:  obj.Inside,
+		outside: obj.Outside,
+		count:   obj.Count,
+	}
+
+	return nil
+}
+
+type credentialsInfoJSON struct {
+	Uids   [4]int       `json:"uids"`
+	Gids   [4]int       `json:"gids"`
+	Groups []int        `json:"groups"`
+	NsPid  []int        `json:"ns_pid"`
+	NsTgid []int        `json:"ns_tgid"`
+	CapInh uint64       `json:"cap_inh"`
+	CapPrm uint64       `json:"cap_prm"`
+	CapEff uint64       `json:"cap_eff"`
+	CapBnd uint64       `json:"cap_bnd"`
+	CapAmb uint64       `json:"cap_amb"`
+	UidMap []*IdMapping `json:"uid_map"`
+	GidMap []*IdMapping `json:"gid_map"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *CredentialsInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(credentialsInfoJSON{
+		Uids:   info.uids,
+		Gids:   info.gids,
+		Groups: info.groups,
+		NsPid:  info.nsPid,
+		NsTgid: info.nsTgid,
+		CapInh: info.capInh,
+		CapPrm: info.capPrm,
+		CapEff: info.capEff,
+		CapBnd: info.capBnd,
+		CapAmb: info.capAmb,
+		UidMap: info.uidMap,
+		GidMap: info.gidMap,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [CredentialsInfo.MarshalJSON].
+func (info *CredentialsInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj credentialsInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = CredentialsInfo{
+		uids:   obj.Uids,
+		gids:   obj.Gids,
+		groups: obj.Groups,
+		nsPid:  obj.NsPid,
+		nsTgid: obj.NsTgid,
+		capInh: obj.CapInh,
+		capPrm: obj.CapPrm,
+		capEff: obj.CapEff,
+		capBnd: obj.CapBnd,
+		capAmb: obj.CapAmb,
+		uidMap: obj.UidMap,
+		gidMap: obj.GidMap,
+	}
+
+	return nil
+}
+
+type edidInfoJSON struct {
+	Manufacturer     string `json:"manufacturer"`
+	ProductCode      int    `json:"product_code"`
+	SerialNumber     uint32 `json:"serial_number"`
+	Week             int    `json:"week"`
+	Year             int    `json:"year"`
+	WidthCm          int    `json:"width_cm"`
+	HeightCm         int    `json:"height_cm"`
+	Name             string `json:"name"`
+	Serial           string `json:"serial"`
+	PreferredWidth   int    `json:"preferred_width"`
+	PreferredHeight  int    `json:"preferred_height"`
+	PreferredClockHz int    `json:"preferred_clock_hz"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *EDIDInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(edidInfoJSON{
+		Manufacturer:     info.manufacturer,
+		ProductCode:      info.productCode,
+		SerialNumber:     info.serialNumber,
+		Week:             info.week,
+		Year:             info.year,
+		WidthCm:          info.widthCm,
+		HeightCm:         info.heightCm,
+		Name:             info.name,
+		Serial:           info.serial,
+		PreferredWidth:   info.preferredWidth,
+		PreferredHeight:  info.preferredHeight,
+		PreferredClockHz: info.preferredClockHz,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [EDIDInfo.MarshalJSON].
+func (info *EDIDInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj edidInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = EDIDInfo{
+		manufacturer:     obj.Manufacturer,
+		productCode:      obj.ProductCode,
+		serialNumber:     obj.SerialNumber,
+		week:             obj.Week,
+		year:             obj.Year,
+		widthCm:          obj.WidthCm,
+		heightCm:         obj.HeightCm,
+		name:             obj.Name,
+		serial:           obj.Serial,
+		preferredWidth:   obj.PreferredWidth,
+		preferredHeight:  obj.PreferredHeight,
+		preferredClockHz: obj.PreferredClockHz,
+	}
+
+	return nil
+}
+
+type drmConnectorInfoJSON struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	Enabled   string         `json:"enabled"`
+	DPMS      string         `json:"dpms"`
+	Modes     []string       `json:"modes"`
+	EDID      *EDIDInfo      `json:"edid,omitempty"`
+	Backlight *BacklightInfo `json:"backlight,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *DRMConnectorInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(drmConnectorInfoJSON{
+		Name:      info.name,
+		Status:    info.status,
+		Enabled:   info.enabled,
+		DPMS:      info.dpms,
+		Modes:     info.modes,
+		EDID:      info.edid,
+		Backlight: info.backlight,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [DRMConnectorInfo.MarshalJSON].
+func (info *DRMConnectorInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj drmConnectorInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = DRMConnectorInfo{
+		name:      obj.Name,
+		status:    obj.Status,
+		enabled:   obj.Enabled,
+		dpms:      obj.DPMS,
+		modes:     obj.Modes,
+		edid:      obj.EDID,
+		backlight: obj.Backlight,
+	}
+
+	return nil
+}
+
+type hostInfoJSON struct {
+	Sysname   string            `json:"sysname"`
+	Nodename  string            `json:"nodename"`
+	Release   string            `json:"release"`
+	Version   string            `json:"version"`
+	Machine   string            `json:"machine"`
+	OSRelease map[string]string `json:"os_release"`
+	MachineId string            `json:"machine_id"`
+	BootId    string            `json:"boot_id"`
+	DMI       map[string]string `json:"dmi"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *HostInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hostInfoJSON{
+		Sysname:   info.sysname,
+		Nodename:  info.nodename,
+		Release:   info.release,
+		Version:   info.version,
+		Machine:   info.machine,
+		OSRelease: info.osRelease,
+		MachineId: info.machineId,
+		BootId:    info.bootId,
+		DMI:       info.dmi,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [HostInfo.MarshalJSON].
+func (info *HostInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj hostInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = HostInfo{
+		sysname:   obj.Sysname,
+		nodename:  obj.Nodename,
+		release:   obj.Release,
+		version:   obj.Version,
+		machine:   obj.Machine,
+		osRelease: obj.OSRelease,
+		machineId: obj.MachineId,
+		bootId:    obj.BootId,
+		dmi:       obj.DMI,
+	}
+
+	return nil
+}
+
+type hugePageInfoJSON struct {
+	Size                  int `json:"size"`
+	Node                  int `json:"node"`
+	NrHugepages           int `json:"nr_hugepages"`
+	FreeHugepages         int `json:"free_hugepages"`
+	ResvHugepages         int `json:"resv_hugepages"`
+	SurplusHugepages      int `json:"surplus_hugepages"`
+	NrOvercommitHugepages int `json:"nr_overcommit_hugepages"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *HugePageInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hugePageInfoJSON{
+		Size:                  info.size,
+		Node:                  info.node,
+		NrHugepages:           info.nrHugepages,
+		FreeHugepages:         info.freeHugepages,
+		ResvHugepages:         info.resvHugepages,
+		SurplusHugepages:      info.surplusHugepages,
+		NrOvercommitHugepages: info.nrOvercommitHugepages,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [HugePageInfo.MarshalJSON].
+func (info *HugePageInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj hugePageInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = HugePageInfo{
+		size:                  obj.Size,
+		node:                  obj.Node,
+		nrHugepages:           obj.NrHugepages,
+		freeHugepages:         obj.FreeHugepages,
+		resvHugepages:         obj.ResvHugepages,
+		surplusHugepages:      obj.SurplusHugepages,
+		nrOvercommitHugepages: obj.NrOvercommitHugepages,
+	}
+
+	return nil
+}
+
+type transparentHugePageInfoJSON struct {
+	Enabled      string         `json:"enabled"`
+	Defrag       string         `json:"defrag"`
+	ShmemEnabled string         `json:"shmem_enabled"`
+	UseZeroPage  int            `json:"use_zero_page"`
+	HpagePmdSize int            `json:"hpage_pmd_size"`
+	Khugepaged   map[string]int `json:"khugepaged"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *TransparentHugePageInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transparentHugePageInfoJSON{
+		Enabled:      info.enabled,
+		Defrag:       info.defrag,
+		ShmemEnabled: info.shmemEnabled,
+		UseZeroPage:  info.useZeroPage,
+		HpagePmdSize: info.hpagePmdSize,
+		Khugepaged:   info.khugepaged,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [TransparentHugePageInfo.MarshalJSON].
+func (info *TransparentHugePageInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj transparentHugePageInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = TransparentHugePageInfo{
+		enabled:      obj.Enabled,
+		defrag:       obj.Defrag,
+		shmemEnabled: obj.ShmemEnabled,
+		useZeroPage:  obj.UseZeroPage,
+		hpagePmdSize: obj.HpagePmdSize,
+		khugepaged:   obj.Khugepaged,
+	}
+
+	return nil
+}
+
+type inputDeviceInfoJSON struct {
+	Bus      int                 `json:"bus"`
+	Vendor   int                 `json:"vendor"`
+	Product  int                 `json:"product"`
+	Version  int                 `json:"version"`
+	Name     string              `json:"name"`
+	Phys     string              `json:"phys"`
+	Sysfs    string              `json:"sysfs"`
+	Uniq     string              `json:"uniq"`
+	Handlers []string            `json:"handlers"`
+	Bitmaps  map[string][]uint64 `json:"bitmaps"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *InputDeviceInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(inputDeviceInfoJSON{
+		Bus:      info.bus,
+		Vendor:   info.vendor,
+		Product:  info.product,
+		Version:  info.version,
+		Name:     info.name,
+		Phys:     info.phys,
+		Sysfs:    info.sysfs,
+		Uniq:     info.uniq,
+		Handlers: info.handlers,
+		Bitmaps:  info.bitmaps,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [InputDeviceInfo.MarshalJSON].
+func (info *InputDeviceInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj inputDeviceInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = InputDeviceInfo{
+		bus:      obj.Bus,
+		vendor:   obj.Vendor,
+		product:  obj.Product,
+		version:  obj.Version,
+		name:     obj.Name,
+		phys:     obj.Phys,
+		sysfs:    obj.Sysfs,
+		uniq:     obj.Uniq,
+		handlers: obj.Handlers,
+		bitmaps:  obj.Bitmaps,
+	}
+
+	return nil
+}
+
+type pciDeviceInfoJSON struct {
+	Address         string `json:"address"`
+	Vendor          int    `json:"vendor"`
+	Device          int    `json:"device"`
+	SubsystemVendor int    `json:"subsystem_vendor"`
+	SubsystemDevice int    `json:"subsystem_device"`
+	Class           int    `json:"class"`
+	Revision        int    `json:"revision"`
+	Driver          string `json:"driver,omitempty"`
+	NumaNode        int    `json:"numa_node"`
+	VendorName      string `json:"vendor_name,omitempty"`
+	DeviceName      string `json:"device_name,omitempty"`
+	ClassName       string `json:"class_name,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *PCIDeviceInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pciDeviceInfoJSON{
+		Address:         info.address,
+		Vendor:          info.vendor,
+		Device:          info.device,
+		SubsystemVendor: info.subsystemVendor,
+		SubsystemDevice: info.subsystemDevice,
+		Class:           info.class,
+		Revision:        info.revision,
+		Driver:          info.driver,
+		NumaNode:        info.numaNode,
+		VendorName:      info.vendorName,
+		DeviceName:      info.deviceName,
+		ClassName:       info.className,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [PCIDeviceInfo.MarshalJSON].
+func (info *PCIDeviceInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj pciDeviceInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = PCIDeviceInfo{
+		address:         obj.Address,
+		vendor:          obj.Vendor,
+		device:          obj.Device,
+		subsystemVendor: obj.SubsystemVendor,
+		subsystemDevice: obj.SubsystemDevice,
+		class:           obj.Class,
+		revision:        obj.Revision,
+		driver:          obj.Driver,
+		numaNode:        obj.NumaNode,
+		vendorName:      obj.VendorName,
+		deviceName:      obj.DeviceName,
+		className:       obj.ClassName,
+	}
+
+	return nil
+}
+
+type usbDeviceInfoJSON struct {
+	Name         string  `json:"name"`
+	VendorId     int     `json:"vendor_id"`
+	ProductId    int     `json:"product_id"`
+	Manufacturer string  `json:"manufacturer,omitempty"`
+	Product      string  `json:"product,omitempty"`
+	Serial       string  `json:"serial,omitempty"`
+	Speed        float64 `json:"speed"`
+	Busnum       int     `json:"busnum"`
+	Devnum       int     `json:"devnum"`
+	Class        int     `json:"class"`
+	Version      string  `json:"version"`
+	Driver       string  `json:"driver,omitempty"`
+	VendorName   string  `json:"vendor_name,omitempty"`
+	ProductName  string  `json:"product_name,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *USBDeviceInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(usbDeviceInfoJSON{
+		Name:         info.name,
+		VendorId:     info.vendorId,
+		ProductId:    info.productId,
+		Manufacturer: info.manufacturer,
+		Product:      info.product,
+		Serial:       info.serial,
+		Speed:        info.speed,
+		Busnum:       info.busnum,
+		Devnum:       info.devnum,
+		Class:        info.class,
+		Version:      info.version,
+		Driver:       info.driver,
+		VendorName:   info.vendorName,
+		ProductName:  info.productName,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [USBDeviceInfo.MarshalJSON].
+func (info *USBDeviceInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj usbDeviceInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = USBDeviceInfo{
+		name:         obj.Name,
+		vendorId:     obj.VendorId,
+		productId:    obj.ProductId,
+		manufacturer: obj.Manufacturer,
+		product:      obj.Product,
+		serial:       obj.Serial,
+		speed:        obj.Speed,
+		busnum:       obj.Busnum,
+		devnum:       obj.Devnum,
+		class:        obj.Class,
+		version:      obj.Version,
+		driver:       obj.Driver,
+		vendorName:   obj.VendorName,
+		productName:  obj.ProductName,
+	}
+
+	return nil
+}
+
+type moduleInfoJSON struct {
+	Name       string   `json:"name"`
+	Size       int      `json:"size"`
+	Refcount   int      `json:"refcount"`
+	Dependants []string `json:"dependants"`
+	State      string   `json:"state"`
+	Taints     string   `json:"taints,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *ModuleInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moduleInfoJSON{
+		Name:       info.name,
+		Size:       info.size,
+		Refcount:   info.refcount,
+		Dependants: info.dependants,
+		State:      info.state,
+		Taints:     info.taints,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [ModuleInfo.MarshalJSON].
+func (info *ModuleInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj moduleInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = ModuleInfo{
+		name:       obj.Name,
+		size:       obj.Size,
+		refcount:   obj.Refcount,
+		dependants: obj.Dependants,
+		state:      obj.State,
+		taints:     obj.Taints,
+	}
+
+	return nil
+}
+
+type rfkillInfoJSON struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Soft  bool   `json:"soft"`
+	Hard  bool   `json:"hard"`
+	State int    `json:"state"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *RfkillInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rfkillInfoJSON{
+		Index: info.index,
+		Type:  info.typ,
+		Name:  info.name,
+		Soft:  info.soft,
+		Hard:  info.hard,
+		State: info.state,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [RfkillInfo.MarshalJSON].
+func (info *RfkillInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj rfkillInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = RfkillInfo{
+		index: obj.Index,
+		typ:   obj.Type,
+		name:  obj.Name,
+		soft:  obj.Soft,
+		hard:  obj.Hard,
+		state: obj.State,
+	}
+
+	return nil
+}
+
+type rfkillEventJSON struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Op    int    `json:"op"`
+	Soft  bool   `json:"soft"`
+	Hard  bool   `json:"hard"`
+}
+
+// MarshalJSON encodes event as a JSON object.
+func (event *RfkillEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rfkillEventJSON{
+		Index: event.index,
+		Type:  event.typ,
+		Op:    event.op,
+		Soft:  event.soft,
+		Hard:  event.hard,
+	})
+}
+
+// UnmarshalJSON decodes event from the object of [RfkillEvent.MarshalJSON].
+func (event *RfkillEvent) UnmarshalJSON(data []byte) error {
+	var (
+		obj rfkillEventJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*event = RfkillEvent{
+		index: obj.Index,
+		typ:   obj.Type,
+		op:    obj.Op,
+		soft:  obj.Soft,
+		hard:  obj.Hard,
+	}
+
+	return nil
+}
+
+type groupInfoJSON struct {
+	Gid  string `json:"gid"`
+	Name string `json:"name"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *GroupInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(groupInfoJSON{
+		Gid:  info.gid,
+		Name: info.name,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [GroupInfo.MarshalJSON].
+func (info *GroupInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj groupInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = GroupInfo{
+		gid:  obj.Gid,
+		name: obj.Name,
+	}
+
+	return nil
+}
+
+type userInfoJSON struct {
+	Uid         string           `json:"uid"`
+	Gid         string           `json:"gid"`
+	Username    string           `json:"username"`
+	Name        string           `json:"name"`
+	Group       string           `json:"group"`
+	Groups      []*GroupInfo     `json:"groups"`
+	HomeDir     string           `json:"home_dir"`
+	Shell       string           `json:"shell"`
+	Hostname    string           `json:"hostname"`
+	Credentials *CredentialsInfo `json:"credentials,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *UserInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userInfoJSON{
+		Uid:         info.uid,
+		Gid:         info.gid,
+		Username:    info.username,
+		Name:        info.name,
+		Group:       info.group,
+		Groups:      info.groups,
+		HomeDir:     info.homeDir,
+		Shell:       info.shell,
+		Hostname:    info.hostname,
+		Credentials: info.credentials,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [UserInfo.MarshalJSON].
+func (info *UserInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj userInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = UserInfo{
+		uid:         obj.Uid,
+		gid:         obj.Gid,
+		username:    obj.Username,
+		name:        obj.Name,
+		group:       obj.Group,
+		groups:      obj.Groups,
+		homeDir:     obj.HomeDir,
+		shell:       obj.Shell,
+		hostname:    obj.Hostname,
+		credentials: obj.Credentials,
+	}
+
+	return nil
+}
+
+type utmpEntryJSON struct {
+	Type        int       `json:"type"`
+	Pid         int       `json:"pid"`
+	Line        string    `json:"line"`
+	Id          string    `json:"id"`
+	User        string    `json:"user"`
+	Host        string    `json:"host"`
+	Termination int       `json:"termination"`
+	Exit        int       `json:"exit"`
+	Session     int       `json:"session"`
+	Time        time.Time `json:"time"`
+	Addr        net.IP    `json:"addr,omitempty"`
+}
+
+func (entry *UtmpEntry) toJSON() utmpEntryJSON {
+	return utmpEntryJSON{
+		Type:        entry.typ,
+		Pid:         entry.pid,
+		Line:        entry.line,
+		Id:          entry.id,
+		User:        entry.user,
+		Host:        entry.host,
+		Termination: entry.termination,
+		Exit:        entry.exit,
+		Session:     entry.session,
+		Time:        entry.time,
+		Addr:        entry.addr,
+	}
+}
+
+func (obj *utmpEntryJSON) entry() UtmpEntry {
+	return UtmpEntry{
+		typ:         obj.Type,
+		pid:         obj.Pid,
+		line:        obj.Line,
+		id:          obj.Id,
+		user:        obj.User,
+		host:        obj.Host,
+		termination: obj.Termination,
+		exit:        obj.Exit,
+		session:     obj.Session,
+		time:        obj.Time,
+		addr:        obj.Addr,
+	}
+}
+
+// MarshalJSON encodes entry as a JSON object.
+func (entry *UtmpEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(entry.toJSON())
+}
+
+// UnmarshalJSON decodes entry from the object of [UtmpEntry.MarshalJSON].
+func (entry *UtmpEntry) UnmarshalJSON(data []byte) error {
+	var (
+		obj utmpEntryJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*entry = obj.entry()
+
+	return nil
+}
+
+type loginInfoJSON struct {
+	utmpEntryJSON
+	Logout time.Time `json:"logout"`
+	Active bool      `json:"active"`
+}
+
+// MarshalJSON encodes info as the object of [UtmpEntry.MarshalJSON]
+// with the logout time and whether if the session is active.
+func (info *LoginInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(loginInfoJSON{
+		utmpEntryJSON: info.toJSON(),
+		Logout:        info.logout,
+		Active:        info.active,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [LoginInfo.MarshalJSON].
+func (info *LoginInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj loginInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = LoginInfo{
+		UtmpEntry: obj.entry(),
+		logout:    obj.Logout,
+		active:    obj.Active,
+	}
+
+	return nil
+}
+
+type virtInfoJSON struct {
+	Hypervisor string `json:"hypervisor,omitempty"`
+	Container  string `json:"container,omitempty"`
+}
+
+// MarshalJSON encodes info as a JSON object.
+func (info *VirtInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(virtInfoJSON{
+		Hypervisor: info.hypervisor,
+		Container:  info.container,
+	})
+}
+
+// UnmarshalJSON decodes info from the object of [VirtInfo.MarshalJSON].
+func (info *VirtInfo) UnmarshalJSON(data []byte) error {
+	var (
+		obj virtInfoJSON
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	*info = VirtInfo{
+		hypervisor: obj.Hypervisor,
+		container:  obj.Container,
+	}
+
+	return nil
+}
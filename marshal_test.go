@@ -0,0 +1,101 @@
+package sstat
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	var (
+		login, logout time.Time
+		tests         []struct{ value, empty any }
+		idx           int
+		data          []byte
+		err           error
+	)
+
+	login = time.Unix(1700000000, 0).UTC()
+	logout = login.Add(time.Hour)
+
+	tests = []struct{ value, empty any }{
+		{&MemInfo{info: map[string]int{"MemTotal": 16309412, "Active(anon)": 2048}}, &MemInfo{}},
+		{&PowerSupplyInfo{info: map[string]string{"POWER_SUPPLY_NAME": "BAT0"}}, &PowerSupplyInfo{}},
+		{&BatteryInfo{PowerSupplyInfo{info: map[string]string{"POWER_SUPPLY_CAPACITY": "87"}}}, &BatteryInfo{}},
+		{&SmapsInfo{info: map[string]int{"Rss": 4}}, &SmapsInfo{}},
+		{&SmapsMapping{SmapsInfo{info: map[string]int{"Pss": 2}}, 0x1000, 0x2000, 0x10, "r-xp", "08:01", 42, "/usr/bin/sh", []string{"rd", "ex"}}, &SmapsMapping{}},
+		{&BacklightInfo{0, 480, 470, 960, "raw", "intel_backlight", "card0-eDP-1"}, &BacklightInfo{}},
+		{&LEDInfo{1, 1, "none", []string{"none", "disk-activity"}, "input3::capslock"}, &LEDInfo{}},
+		{&SoundCardInfo{0, "PCH", "snd_hda_intel", "HDA Intel PCH", "HDA Intel PCH at 0xf7f00000 irq 33"}, &SoundCardInfo{}},
+		{&PCMSubstreamInfo{0, 3, true, 0, "RUNNING", 1234}, &PCMSubstreamInfo{}},
+		{&MixerInfo{"Master", 0, 87, []int{40, 42}, []bool{true, false}, true}, &MixerInfo{}},
+		{&IdMapping{0, 100000, 65536}, &IdMapping{}},
+		{&CredentialsInfo{
+			uids: [4]int{1000, 1000, 1000, 1000}, gids: [4]int{100, 100, 100, 100},
+			groups: []int{10, 100}, nsPid: []int{42}, nsTgid: []int{42},
+			capBnd: 0x1ffffffffff, uidMap: []*IdMapping{{0, 0, 4294967295}}, gidMap: []*IdMapping{{0, 0, 4294967295}},
+		}, &CredentialsInfo{}},
+		{&EDIDInfo{"DEL", 0xa0ec, 1234, 12, 2020, 60, 34, "DELL U2720Q", "ABC123", 3840, 2160, 533250000}, &EDIDInfo{}},
+		{&DRMConnectorInfo{
+			name: "card0-eDP-1", status: "connected", enabled: "enabled", dpms: "On", modes: []string{"1920x1080"},
+			edid: &EDIDInfo{manufacturer: "BOE"}, backlight: &BacklightInfo{name: "intel_backlight", typ: "raw"},
+		}, &DRMConnectorInfo{}},
+		{&HostInfo{"Linux", "laptop", "6.6.0", "#1 SMP", "x86_64", map[string]string{"ID": "arch"}, "abc", "def", map[string]string{"sys_vendor": "LENOVO"}}, &HostInfo{}},
+		{&HugePageInfo{2048, -1, 4, 2, 1, 0, 0}, &HugePageInfo{}},
+		{&TransparentHugePageInfo{"madvise", "madvise", "never", 1, 2097152, map[string]int{"pages_to_scan": 4096}}, &TransparentHugePageInfo{}},
+		{&InputDeviceInfo{0x11, 1, 1, 0xab41, "AT Translated Set 2 keyboard", "isa0060/serio0/input0", "/devices/platform/i8042/serio0/input/input3", "", []string{"kbd", "event3"}, map[string][]uint64{"EV": {0x120013}}}, &InputDeviceInfo{}},
+		{&PCIDeviceInfo{"0000:00:02.0", 0x8086, 0x9a49, 0x17aa, 0x22d8, 0x030000, 1, "i915", -1, "Intel Corporation", "TigerLake-LP GT2", "VGA compatible controller"}, &PCIDeviceInfo{}},
+		{&USBDeviceInfo{"1-1", 0x046d, 0xc52b, "Logitech", "USB Receiver", "", 12, 1, 4, 0, "2.00", "usb", "Logitech, Inc.", "Unifying Receiver"}, &USBDeviceInfo{}},
+		{&ModuleInfo{"i915", 4194304, 12, []string{"kvmgt"}, "Live", "OE"}, &ModuleInfo{}},
+		{&RfkillInfo{0, "wlan", "phy0", true, false, 0}, &RfkillInfo{}},
+		{&RfkillEvent{3, "bluetooth", RfkillOpChange, true, false}, &RfkillEvent{}},
+		{&GroupInfo{"100", "users"}, &GroupInfo{}},
+		{&UserInfo{"1000", "100", "alice", "Alice", "users", []*GroupInfo{{"100", "users"}}, "/home/alice", "/bin/sh", "laptop", &CredentialsInfo{groups: []int{100}}}, &UserInfo{}},
+		{&UtmpEntry{UtmpUserProcess, 1234, "pts/0", "ts/0", "alice", "10.0.0.2", 0, 0, 1234, login, net.ParseIP("10.0.0.2")}, &UtmpEntry{}},
+		{&LoginInfo{UtmpEntry{typ: UtmpUserProcess, user: "alice", line: "tty1", time: login}, logout, false}, &LoginInfo{}},
+		{&VirtInfo{"kvm", "docker"}, &VirtInfo{}},
+	}
+
+	for idx = range tests {
+		data, err = json.Marshal(tests[idx].value)
+		if err != nil {
+			t.Errorf("%T: %v", tests[idx].value, err)
+
+			continue
+		}
+
+		err = json.Unmarshal(data, tests[idx].empty)
+		if err != nil {
+			t.Errorf("%T: %v", tests[idx].value, err)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(tests[idx].value, tests[idx].empty) {
+			t.Errorf("%T: expected %+v, got %+v from %s", tests[idx].value, tests[idx].value, tests[idx].empty, data)
+		}
+	}
+}
+
+func TestMarshalJSONSchema(t *testing.T) {
+	var (
+		data []byte
+		err  error
+	)
+
+	data, err = json.Marshal(&BacklightInfo{0, 480, 470, 960, "raw", "intel_backlight", ""})
+	tErrorIf(t, err)
+
+	if string(data) != `{"bl_power":0,"brightness":480,"actual_brightness":470,"max_brightness":960,"type":"raw","name":"intel_backlight"}` {
+		t.Errorf("unexpected BacklightInfo JSON %s", data)
+	}
+
+	data, err = json.Marshal(&BatteryInfo{PowerSupplyInfo{info: map[string]string{"POWER_SUPPLY_STATUS": "Full"}}})
+	tErrorIf(t, err)
+
+	if string(data) != `{"POWER_SUPPLY_STATUS":"Full"}` {
+		t.Errorf("unexpected BatteryInfo JSON %s", data)
+	}
+}
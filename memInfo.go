@@ -491,8 +491,7 @@ func (info *MemInfo) DirectMap1G() (value int, ok bool) {
 	return info.Key("DirectMap1G")
 }
 
-// NewMemInfo returns memory usage information from [MemInfoPath].
-func NewMemInfo() (*MemInfo, error) {
+func newMemInfo(path string) (*MemInfo, error) {
 	var (
 		memInfo *MemInfo
 		err     error
@@ -502,7 +501,7 @@ func NewMemInfo() (*MemInfo, error) {
 		info: make(map[string]int),
 	}
 
-	err = ScanFile(MemInfoPath, bufio.ScanLines, func(text string) (bool, error) {
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
 		var (
 			fields []string
 			value  int
@@ -511,7 +510,7 @@ func NewMemInfo() (*MemInfo, error) {
 
 		fields = strings.Fields(text)
 		if len(fields) != 2 && len(fields) != 3 {
-			return false, fmt.Errorf("%s: invalid meminfo format", MemInfoPath)
+			return false, fmt.Errorf("%s: invalid meminfo format", path)
 		}
 
 		value, err = strconv.Atoi(fields[1])
@@ -526,3 +525,8 @@ func NewMemInfo() (*MemInfo, error) {
 
 	return memInfo, err
 }
+
+// NewMemInfo returns memory usage information from [MemInfoPath].
+func NewMemInfo() (*MemInfo, error) {
+	return newMemInfo(MemInfoPath)
+}
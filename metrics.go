@@ -0,0 +1,383 @@
+package sstat
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MetricsContentType is the content type of the
+// Prometheus text exposition format written by [WriteMetrics].
+const MetricsContentType string = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricLabel is a single name="value" pair of a sample.
+type metricLabel struct {
+	name, value string
+}
+
+// metricSample is a single value of a metric family.
+type metricSample struct {
+	labels []metricLabel
+	value  float64
+}
+
+// MetricFamily reports a family of Prometheus gauges sharing
+// a name, such as every sstat_backlight_brightness sample.
+// Values are in base SI units, e.g. bytes, joules and watts.
+type MetricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+// Name reports the name of the family, e.g. "sstat_memory_MemTotal_bytes".
+func (family *MetricFamily) Name() (value string) {
+	return family.name
+}
+
+// Help reports the description of the family.
+func (family *MetricFamily) Help() (value string) {
+	return family.help
+}
+
+// Len reports the number of samples of the family.
+func (family *MetricFamily) Len() (value int) {
+	return len(family.samples)
+}
+
+// add appends a sample of value with labels given as name, value pairs.
+func (family *MetricFamily) add(value float64, labels ...string) {
+	var (
+		sample metricSample
+		idx    int
+	)
+
+	sample.value = value
+
+	for idx = 0; idx+1 < len(labels); idx += 2 {
+		sample.labels = append(sample.labels, metricLabel{labels[idx], labels[idx+1]})
+	}
+
+	family.samples = append(family.samples, sample)
+}
+
+// metricFamilies groups families by name in order of creation.
+type metricFamilies struct {
+	families []*MetricFamily
+	byName   map[string]*MetricFamily
+}
+
+func (families *metricFamilies) family(name, help string) *MetricFamily {
+	var (
+		family *MetricFamily
+		ok     bool
+	)
+
+	if families.byName == nil {
+		families.byName = make(map[string]*MetricFamily)
+	}
+
+	family, ok = families.byName[name]
+	if ok {
+		return family
+	}
+
+	family = &MetricFamily{
+		name: name,
+		help: help,
+	}
+
+	families.byName[name] = family
+	families.families = append(families.families, family)
+
+	return family
+}
+
+var memInfoMetricReplacer = strings.NewReplacer("(", "_", ")", "")
+
+// MemInfoMetrics reports every field of info as a gauge named
+// "sstat_memory_" + field + "_bytes", e.g. "sstat_memory_MemTotal_bytes".
+// The HugePages_ fields are page counts and have no unit suffix.
+func MemInfoMetrics(info *MemInfo) []*MetricFamily {
+	var (
+		families metricFamilies
+		keys     []string
+		key      string
+		name     string
+	)
+
+	for key = range info.info {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key = range keys {
+		name = "sstat_memory_" + memInfoMetricReplacer.Replace(key)
+
+		if strings.HasPrefix(key, "HugePages_") {
+			families.family(name, "Memory information field "+key+" from "+MemInfoPath+".").add(float64(info.info[key]))
+
+			continue
+		}
+
+		families.family(name+"_bytes", "Memory information field "+key+" from "+MemInfoPath+", in bytes.").add(float64(info.info[key]) * 1024)
+	}
+
+	return families.families
+}
+
+// powerSupplyMetrics are the uevent keys of power supplies exported
+// by [PowerSupplyMetrics], with the value in base SI units being
+// the uevent value * mul / div.
+var powerSupplyMetrics = []struct {
+	key, name, help string
+	mul, div        float64
+}{
+	{"POWER_SUPPLY_ONLINE", "online", "Whether the power supply is online.", 1, 1},
+	{"POWER_SUPPLY_PRESENT", "present", "Whether the power supply is present.", 1, 1},
+	{"POWER_SUPPLY_CAPACITY", "capacity_ratio", "Capacity of the battery from 0 to 1.", 1, 100},
+	{"POWER_SUPPLY_ENERGY_NOW", "energy_joules", "Energy stored in the battery in joules.", 3600, 1e6},
+	{"POWER_SUPPLY_ENERGY_FULL", "energy_full_joules", "Energy stored in the battery when full in joules.", 3600, 1e6},
+	{"POWER_SUPPLY_ENERGY_FULL_DESIGN", "energy_full_design_joules", "Design energy of the battery when full in joules.", 3600, 1e6},
+	{"POWER_SUPPLY_CHARGE_NOW", "charge_coulombs", "Charge stored in the battery in coulombs.", 3600, 1e6},
+	{"POWER_SUPPLY_CHARGE_FULL", "charge_full_coulombs", "Charge stored in the battery when full in coulombs.", 3600, 1e6},
+	{"POWER_SUPPLY_CHARGE_FULL_DESIGN", "charge_full_design_coulombs", "Design charge of the battery when full in coulombs.", 3600, 1e6},
+	{"POWER_SUPPLY_POWER_NOW", "power_watts", "Power drawn from or supplied to the battery in watts.", 1, 1e6},
+	{"POWER_SUPPLY_CURRENT_NOW", "current_amperes", "Current drawn from or supplied to the battery in amperes.", 1, 1e6},
+	{"POWER_SUPPLY_VOLTAGE_NOW", "voltage_volts", "Voltage of the power supply in volts.", 1, 1e6},
+}
+
+// PowerSupplyMetrics reports the online state of every power supply of
+// infos and the energy, charge, power and capacity of every battery of
+// infos as gauges named "sstat_power_supply_" + metric, labelled
+// by the device name and type of the power supply. Missing or
+// non-numeric uevent keys are skipped.
+func PowerSupplyMetrics(infos []*PowerSupplyInfo) []*MetricFamily {
+	var (
+		families    metricFamilies
+		name, typ   string
+		str         string
+		value       float64
+		idx, metric int
+		ok          bool
+		err         error
+	)
+
+	for idx = range infos {
+		name, _ = infos[idx].Name()
+		typ, _ = infos[idx].Type()
+
+		for metric = range powerSupplyMetrics {
+			str, ok = infos[idx].Key(powerSupplyMetrics[metric].key)
+			if !ok {
+				continue
+			}
+
+			value, err = strconv.ParseFloat(str, 64)
+			if err != nil {
+				continue
+			}
+
+			families.family("sstat_power_supply_"+powerSupplyMetrics[metric].name, powerSupplyMetrics[metric].help).
+				add(value*powerSupplyMetrics[metric].mul/powerSupplyMetrics[metric].div, "device", name, "type", typ)
+		}
+	}
+
+	return families.families
+}
+
+// BacklightMetrics reports the brightness of every backlight of infos
+// as gauges named "sstat_backlight_" + metric, labelled by the device
+// name and type of the backlight.
+func BacklightMetrics(infos []*BacklightInfo) []*MetricFamily {
+	var (
+		families metricFamilies
+		idx      int
+	)
+
+	for idx = range infos {
+		families.family("sstat_backlight_brightness", "Requested brightness of the backlight.").
+			add(float64(infos[idx].Brightness()), "device", infos[idx].Name(), "type", infos[idx].Type())
+		families.family("sstat_backlight_actual_brightness", "Actual brightness of the backlight.").
+			add(float64(infos[idx].ActualBrightness()), "device", infos[idx].Name(), "type", infos[idx].Type())
+		families.family("sstat_backlight_max_brightness", "Maximum brightness of the backlight.").
+			add(float64(infos[idx].MaxBrightness()), "device", infos[idx].Name(), "type", infos[idx].Type())
+
+		if infos[idx].MaxBrightness() != 0 {
+			families.family("sstat_backlight_brightness_ratio", "Actual brightness of the backlight from 0 to 1.").
+				add(float64(infos[idx].ActualBrightness())/float64(infos[idx].MaxBrightness()), "device", infos[idx].Name(), "type", infos[idx].Type())
+		}
+	}
+
+	return families.families
+}
+
+// GatherMetrics reports [MemInfoMetrics], [PowerSupplyMetrics]
+// of every power supply and [BacklightMetrics] of every backlight.
+func GatherMetrics() ([]*MetricFamily, error) {
+	var (
+		memInfo          *MemInfo
+		powerSupplyInfos []*PowerSupplyInfo
+		backlightInfos   []*BacklightInfo
+		families         []*MetricFamily
+		err              error
+	)
+
+	memInfo, err = NewMemInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	powerSupplyInfos, err = PowerSupplies("*")
+	if err != nil {
+		return nil, err
+	}
+
+	backlightInfos, err = Backlights("*")
+	if err != nil {
+		return nil, err
+	}
+
+	families = append(families, MemInfoMetrics(memInfo)...)
+	families = append(families, PowerSupplyMetrics(powerSupplyInfos)...)
+	families = append(families, BacklightMetrics(backlightInfos)...)
+
+	return families, nil
+}
+
+var (
+	metricHelpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	metricLabelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// WriteMetrics writes families in the Prometheus text exposition
+// format, sorted by name, with the samples of each family in order.
+func WriteMetrics(writer io.Writer, families []*MetricFamily) error {
+	var (
+		bufWriter *bufio.Writer
+		sorted    []*MetricFamily
+		family    *MetricFamily
+		sample    metricSample
+		idx       int
+	)
+
+	sorted = slices.Clone(families)
+	slices.SortStableFunc(sorted, func(a, b *MetricFamily) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	bufWriter = bufio.NewWriter(writer)
+
+	for _, family = range sorted {
+		bufWriter.WriteString("# HELP " + family.name + " " + metricHelpReplacer.Replace(family.help) + "\n")
+		bufWriter.WriteString("# TYPE " + family.name + " gauge\n")
+
+		for _, sample = range family.samples {
+			bufWriter.WriteString(family.name)
+
+			for idx = range sample.labels {
+				if idx == 0 {
+					bufWriter.WriteByte('{')
+				} else {
+					bufWriter.WriteByte(',')
+				}
+
+				bufWriter.WriteString(sample.labels[idx].name + `="` + metricLabelReplacer.Replace(sample.labels[idx].value) + `"`)
+			}
+
+			if len(sample.labels) != 0 {
+				bufWriter.WriteByte('}')
+			}
+
+			bufWriter.WriteString(" " + strconv.FormatFloat(sample.value, 'g', -1, 64) + "\n")
+		}
+	}
+
+	return bufWriter.Flush()
+}
+
+// MetricsHandler returns an [http.Handler] serving [GatherMetrics]
+// in the Prometheus text exposition format at every request. The
+// metrics are rendered before anything is sent, so that an error is
+// reported as a 500 response rather than as a truncated body.
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var (
+			families []*MetricFamily
+			buf      bytes.Buffer
+			err      error
+		)
+
+		families, err = GatherMetrics()
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		err = WriteMetrics(&buf, families)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", MetricsContentType)
+		writer.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+
+		buf.WriteTo(writer)
+	})
+}
+
+// WriteMetricsFile writes families to path for the textfile collector
+// of the Prometheus node exporter. The file is written to a temporary
+// file in the same directory first and renamed to path so that the
+// collector never reads a partial file.
+func WriteMetricsFile(path string, families []*MetricFamily) error {
+	var (
+		file *os.File
+		err  error
+	)
+
+	file, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	err = WriteMetrics(file, families)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	err = file.Chmod(0o644)
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+
+	if err != nil {
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package sstat_test
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Serve the metrics to Prometheus at http://localhost:9100/metrics.
+func ExampleMetricsHandler() {
+	http.Handle("/metrics", sstat.MetricsHandler())
+
+	panic(http.ListenAndServe(":9100", nil))
+}
+
+// Write the metrics for the textfile collector of the node exporter.
+func ExampleWriteMetricsFile() {
+	var (
+		families []*sstat.MetricFamily
+		err      error
+	)
+
+	families, err = sstat.GatherMetrics()
+	if err != nil {
+		panic(err)
+	}
+
+	err = sstat.WriteMetricsFile("/var/lib/node_exporter/textfile_collector/sstat.prom", families)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Print the metrics in the Prometheus text exposition format.
+func ExampleWriteMetrics() {
+	var (
+		families []*sstat.MetricFamily
+		err      error
+	)
+
+	families, err = sstat.GatherMetrics()
+	if err != nil {
+		panic(err)
+	}
+
+	sstat.WriteMetrics(os.Stdout, families)
+}
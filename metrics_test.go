@@ -0,0 +1,145 @@
+package sstat
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func metricsFixture() []*MetricFamily {
+	var families []*MetricFamily
+
+	families = append(families, MemInfoMetrics(&MemInfo{info: map[string]int{
+		"MemTotal":        16309412,
+		"MemAvailable":    9861240,
+		"Active(anon)":    2048,
+		"HugePages_Total": 4,
+		"Hugepagesize":    2048,
+	}})...)
+
+	families = append(families, PowerSupplyMetrics([]*PowerSupplyInfo{
+		{info: map[string]string{
+			"POWER_SUPPLY_NAME":   "AC",
+			"POWER_SUPPLY_TYPE":   "Mains",
+			"POWER_SUPPLY_ONLINE": "1",
+		}},
+		{info: map[string]string{
+			"POWER_SUPPLY_NAME":               "BAT0",
+			"POWER_SUPPLY_TYPE":               "Battery",
+			"POWER_SUPPLY_STATUS":             "Discharging",
+			"POWER_SUPPLY_PRESENT":            "1",
+			"POWER_SUPPLY_CAPACITY":           "87",
+			"POWER_SUPPLY_ENERGY_NOW":         "45240000",
+			"POWER_SUPPLY_ENERGY_FULL":        "52000000",
+			"POWER_SUPPLY_ENERGY_FULL_DESIGN": "57000000",
+			"POWER_SUPPLY_POWER_NOW":          "7512000",
+			"POWER_SUPPLY_VOLTAGE_NOW":        "12345000",
+		}},
+		{info: map[string]string{
+			"POWER_SUPPLY_NAME":        "hid-\"mouse\"-battery",
+			"POWER_SUPPLY_TYPE":        "Battery",
+			"POWER_SUPPLY_CAPACITY":    "n/a",
+			"POWER_SUPPLY_CHARGE_NOW":  "1500000",
+			"POWER_SUPPLY_CURRENT_NOW": "250000",
+		}},
+	})...)
+
+	families = append(families, BacklightMetrics([]*BacklightInfo{
+		{brightness: 480, actualBrightness: 480, maxBrightness: 960, typ: "raw", name: "intel_backlight"},
+		{brightness: 0, actualBrightness: 0, maxBrightness: 0, typ: "firmware", name: "acpi_video0"},
+	})...)
+
+	return families
+}
+
+func TestWriteMetrics(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		golden string
+		want   []byte
+		err    error
+	)
+
+	err = WriteMetrics(&buf, metricsFixture())
+	tErrorIf(t, err)
+
+	golden = filepath.Join("testdata", "metrics.prom")
+
+	if *updateGolden {
+		err = os.WriteFile(golden, buf.Bytes(), 0o644)
+		tErrorIf(t, err)
+	}
+
+	want, err = os.ReadFile(golden)
+	tErrorIf(t, err)
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("WriteMetrics output differs from %s, run go test -update to regenerate it:\n%s", golden, buf.String())
+	}
+}
+
+func TestWriteMetricsFile(t *testing.T) {
+	var (
+		path    string
+		buf     bytes.Buffer
+		got     []byte
+		entries []os.DirEntry
+		err     error
+	)
+
+	path = filepath.Join(t.TempDir(), "sstat.prom")
+
+	err = WriteMetricsFile(path, metricsFixture())
+	tErrorIf(t, err)
+
+	err = WriteMetrics(&buf, metricsFixture())
+	tErrorIf(t, err)
+
+	got, err = os.ReadFile(path)
+	tErrorIf(t, err)
+
+	if !bytes.Equal(got, buf.Bytes()) {
+		t.Errorf("WriteMetricsFile wrote %q, want %q", got, buf.String())
+	}
+
+	entries, err = os.ReadDir(filepath.Dir(path))
+	tErrorIf(t, err)
+
+	if len(entries) != 1 {
+		t.Errorf("WriteMetricsFile left %d files, want 1", len(entries))
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	var recorder *httptest.ResponseRecorder
+
+	if !checkPath(t, MemInfoPath) {
+		return
+	}
+
+	recorder = httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	if recorder.Header().Get("Content-Type") != MetricsContentType {
+		t.Errorf("expected Content-Type %q, got %q", MetricsContentType, recorder.Header().Get("Content-Type"))
+	}
+
+	if recorder.Header().Get("Content-Length") != strconv.Itoa(recorder.Body.Len()) {
+		t.Errorf("expected Content-Length %d, got %q", recorder.Body.Len(), recorder.Header().Get("Content-Length"))
+	}
+
+	if !bytes.Contains(recorder.Body.Bytes(), []byte("\nsstat_memory_MemTotal_bytes ")) {
+		t.Errorf("expected sstat_memory_MemTotal_bytes, got:\n%s", recorder.Body.String())
+	}
+}
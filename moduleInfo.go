@@ -0,0 +1,313 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ModulesPath is the path to the file where the
+// loaded kernel module information is located.
+const ModulesPath string = "/proc/modules"
+
+// ModuleSysPath is the directory where the information
+// for kernel modules, including their parameters, are located.
+const ModuleSysPath string = "/sys/module"
+
+// TaintedPath is the path to the file where the
+// kernel taint bitmask is located.
+const TaintedPath string = "/proc/sys/kernel/tainted"
+
+// Bits of the kernel taint bitmask as documented in [tainted-kernels].
+//
+// [tainted-kernels]: https://docs.kernel.org/admin-guide/tainted-kernels.html
+const (
+	TaintProprietaryModule uint64 = 1 << iota
+	TaintForcedModule
+	TaintCPUOutOfSpec
+	TaintForcedRmmod
+	TaintMachineCheck
+	TaintBadPage
+	TaintUser
+	TaintDie
+	TaintOverriddenACPITable
+	TaintWarn
+	TaintCrap
+	TaintFirmwareWorkaround
+	TaintOOTModule
+	TaintUnsignedModule
+	TaintSoftlockup
+	TaintLivepatch
+	TaintAux
+	TaintRandstruct
+	TaintTest
+	TaintFwctl
+)
+
+// taintFlags are the letters and names of the kernel taint
+// flags from kernel/panic.c indexed by their bit number.
+var taintFlags = []struct {
+	letter byte
+	name   string
+}{
+	{'P', "proprietary_module"},
+	{'F', "forced_module"},
+	{'S', "cpu_out_of_spec"},
+	{'R', "forced_rmmod"},
+	{'M', "machine_check"},
+	{'B', "bad_page"},
+	{'U', "user"},
+	{'D', "die"},
+	{'A', "overridden_acpi_table"},
+	{'W', "warn"},
+	{'C', "crap"},
+	{'I', "firmware_workaround"},
+	{'O', "oot_module"},
+	{'E', "unsigned_module"},
+	{'L', "softlockup"},
+	{'K', "livepatch"},
+	{'X', "aux"},
+	{'T', "randstruct"},
+	{'N', "test"},
+	{'J', "fwctl"},
+}
+
+// TaintNames reports the names of the taint flags set in mask,
+// e.g. "oot_module" for [TaintOOTModule]. Unknown bits are
+// reported as "taint_" followed by the bit number.
+func TaintNames(mask uint64) []string {
+	var (
+		names []string
+		bit   int
+	)
+
+	for mask != 0 {
+		bit = bits.TrailingZeros64(mask)
+		mask &^= 1 << bit
+
+		if bit < len(taintFlags) {
+			names = append(names, taintFlags[bit].name)
+
+			continue
+		}
+
+		names = append(names, "taint_"+strconv.Itoa(bit))
+	}
+
+	return names
+}
+
+// ModuleInfo reports a loaded kernel module from [ModulesPath].
+type ModuleInfo struct {
+	name       string
+	size       int
+	refcount   int
+	dependants []string
+	state      string
+	taints     string
+}
+
+// Name reports the name of the module, e.g. "i915".
+func (info *ModuleInfo) Name() (value string) {
+	return info.name
+}
+
+// Size reports the memory size of the module in bytes.
+func (info *ModuleInfo) Size() (value int) {
+	return info.size
+}
+
+// Refcount reports the number of references to the module.
+// It is -1 if the module cannot be unloaded.
+func (info *ModuleInfo) Refcount() (value int) {
+	return info.refcount
+}
+
+// Dependants reports the names of the loaded modules
+// that depend on the module.
+func (info *ModuleInfo) Dependants() (value []string) {
+	return info.dependants
+}
+
+// State reports the load state of the module.
+//
+// Valid values are:
+//   - "Live"
+//   - "Loading"
+//   - "Unloading"
+func (info *ModuleInfo) State() (value string) {
+	return info.state
+}
+
+// Taints reports the taint letters of the module as shown
+// by the kernel, e.g. "POE". It is blank for untainted modules.
+func (info *ModuleInfo) Taints() (value string) {
+	return info.taints
+}
+
+// TaintMask reports the taint letters of the module as a bitmask of
+// the Taint constants, such as [TaintOOTModule], see [TaintNames].
+func (info *ModuleInfo) TaintMask() (value uint64) {
+	var (
+		letter byte
+		bit    int
+	)
+
+	for _, letter = range []byte(info.taints) {
+		for bit = range taintFlags {
+			if taintFlags[bit].letter == letter {
+				value |= 1 << bit
+			}
+		}
+	}
+
+	return value
+}
+
+func parseModule(text string) (*ModuleInfo, error) {
+	var (
+		moduleInfo *ModuleInfo
+		fields     []string
+		name       string
+		nums       []int
+		err        error
+	)
+
+	fields = strings.Fields(text)
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("%s: invalid module format", text)
+	}
+
+	moduleInfo = &ModuleInfo{
+		name:  fields[0],
+		state: fields[4],
+	}
+
+	if fields[2] == "-" {
+		fields[2] = "-1"
+	}
+
+	nums, err = parseIntFields(fields[1:3])
+	if err != nil {
+		return nil, err
+	}
+
+	moduleInfo.size, moduleInfo.refcount = nums[0], nums[1]
+
+	// The kernel appends "[unsafe]" and "[permanent]" markers to the
+	// dependants, which are not module names.
+	for _, name = range strings.Split(strings.TrimSuffix(fields[3], ","), ",") {
+		if name != "-" && !strings.HasPrefix(name, "[") {
+			moduleInfo.dependants = append(moduleInfo.dependants, name)
+		}
+	}
+
+	if len(fields) > 6 {
+		moduleInfo.taints = strings.Trim(fields[6], "()")
+	}
+
+	return moduleInfo, nil
+}
+
+func modules(path string) ([]*ModuleInfo, error) {
+	var (
+		moduleInfos []*ModuleInfo
+		err         error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			moduleInfo *ModuleInfo
+			err        error
+		)
+
+		moduleInfo, err = parseModule(text)
+		if err != nil {
+			return false, err
+		}
+
+		moduleInfos = append(moduleInfos, moduleInfo)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moduleInfos, nil
+}
+
+// Modules returns every loaded kernel module in [ModulesPath].
+func Modules() ([]*ModuleInfo, error) {
+	return modules(ModulesPath)
+}
+
+func moduleParameters(dir, name string) (map[string]string, error) {
+	var (
+		params  map[string]string
+		entries []os.DirEntry
+		idx     int
+		value   string
+		err     error
+	)
+
+	params = make(map[string]string)
+
+	entries, err = os.ReadDir(filepath.Join(dir, name, "parameters"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return params, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx = range entries {
+		value, err = PathReadStr(filepath.Join(dir, name, "parameters", entries[idx].Name()))
+		if errors.Is(err, fs.ErrPermission) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		params[entries[idx].Name()] = value
+	}
+
+	return params, nil
+}
+
+// ModuleParameters returns the parameters of the kernel module name
+// from [ModuleSysPath]. Write-only parameters and parameters that
+// require root are skipped. Modules without parameters report an
+// empty map.
+func ModuleParameters(name string) (map[string]string, error) {
+	return moduleParameters(ModuleSysPath, name)
+}
+
+func kernelTaint(path string) (uint64, error) {
+	var (
+		str string
+		err error
+	)
+
+	str, err = PathReadStr(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(str, 10, 64)
+}
+
+// KernelTaint returns the kernel taint bitmask from [TaintedPath].
+// It is 0 for untainted kernels, see [TaintNames].
+func KernelTaint() (uint64, error) {
+	return kernelTaint(TaintedPath)
+}
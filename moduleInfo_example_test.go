@@ -0,0 +1,42 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print every out-of-tree or proprietary kernel module.
+func ExampleModules() {
+	var (
+		moduleInfos []*sstat.ModuleInfo
+		idx         int
+		err         error
+	)
+
+	moduleInfos, err = sstat.Modules()
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range moduleInfos {
+		if moduleInfos[idx].TaintMask()&(sstat.TaintOOTModule|sstat.TaintProprietaryModule) != 0 {
+			fmt.Println(moduleInfos[idx].Name(), sstat.TaintNames(moduleInfos[idx].TaintMask()))
+		}
+	}
+}
+
+// Print why the kernel is tainted.
+func ExampleKernelTaint() {
+	var (
+		mask uint64
+		err  error
+	)
+
+	mask, err = sstat.KernelTaint()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(sstat.TaintNames(mask))
+}
@@ -0,0 +1,133 @@
+package sstat
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestModules(t *testing.T) {
+	var (
+		root        string
+		moduleInfos []*ModuleInfo
+		err         error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"modules": "nvidia_uvm 1830912 0 - Live 0x0000000000000000 (POE)\n" +
+			"nvidia 56885248 45 nvidia_uvm,nvidia_modeset, Live 0x0000000000000000 (POE)\n" +
+			"snd_hda_intel 61440 3 - Live 0x0000000000000000\n" +
+			"crc32c_intel 24576 - - Loading 0x0000000000000000\n" +
+			"sunrpc 774144 1 [permanent], Live 0x0000000000000000\n" +
+			"ext4 1105920 1 jbd2,[permanent], Live 0x0000000000000000\n",
+	})
+
+	moduleInfos, err = modules(filepath.Join(root, "modules"))
+	tErrorIf(t, err)
+
+	if len(moduleInfos) != 6 {
+		t.Fatalf("expected 6 modules, got %d", len(moduleInfos))
+	}
+
+	if moduleInfos[1].Name() != "nvidia" || moduleInfos[1].Size() != 56885248 || moduleInfos[1].Refcount() != 45 || !slices.Equal(moduleInfos[1].Dependants(), []string{"nvidia_uvm", "nvidia_modeset"}) {
+		t.Errorf("unexpected nvidia module %+v", moduleInfos[1])
+	}
+
+	if moduleInfos[1].Taints() != "POE" || moduleInfos[1].TaintMask() != TaintProprietaryModule|TaintOOTModule|TaintUnsignedModule {
+		t.Errorf("unexpected nvidia taints %q", moduleInfos[1].Taints())
+	}
+
+	if moduleInfos[2].Dependants() != nil || moduleInfos[2].TaintMask() != 0 || moduleInfos[2].State() != "Live" {
+		t.Errorf("unexpected snd_hda_intel module %+v", moduleInfos[2])
+	}
+
+	if moduleInfos[3].Refcount() != -1 || moduleInfos[3].State() != "Loading" {
+		t.Errorf("unexpected crc32c_intel module %+v", moduleInfos[3])
+	}
+
+	if moduleInfos[4].Dependants() != nil {
+		t.Errorf("expected no sunrpc dependants, got %q", moduleInfos[4].Dependants())
+	}
+
+	if !slices.Equal(moduleInfos[5].Dependants(), []string{"jbd2"}) {
+		t.Errorf("expected %q, got %q", []string{"jbd2"}, moduleInfos[5].Dependants())
+	}
+
+	_, err = parseModule("broken 1")
+	if err == nil {
+		t.Error("expected error for invalid module line")
+	}
+
+	if !checkPath(t, ModulesPath) {
+		return
+	}
+
+	_, err = Modules()
+	tErrorIf(t, err)
+}
+
+func TestModuleParameters(t *testing.T) {
+	var (
+		root   string
+		params map[string]string
+		err    error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"i915/parameters/enable_guc": "-1\n",
+		"i915/parameters/modeset":    "1\n",
+		"i915/refcnt":                "3\n",
+		"acpi/uevent":                "\n",
+	})
+
+	params, err = moduleParameters(root, "i915")
+	tErrorIf(t, err)
+
+	if len(params) != 2 || params["enable_guc"] != "-1" || params["modeset"] != "1" {
+		t.Errorf("unexpected i915 parameters %v", params)
+	}
+
+	params, err = moduleParameters(root, "acpi")
+	tErrorIf(t, err)
+
+	if len(params) != 0 {
+		t.Errorf("expected no acpi parameters, got %v", params)
+	}
+
+	if !checkPath(t, ModuleSysPath) {
+		return
+	}
+
+	_, err = ModuleParameters("kernel")
+	tErrorIf(t, err)
+}
+
+func TestKernelTaint(t *testing.T) {
+	var (
+		root string
+		mask uint64
+		err  error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"tainted": "12289\n",
+	})
+
+	mask, err = kernelTaint(filepath.Join(root, "tainted"))
+	tErrorIf(t, err)
+
+	if !slices.Equal(TaintNames(mask), []string{"proprietary_module", "oot_module", "unsigned_module"}) {
+		t.Errorf("unexpected taint names %v", TaintNames(mask))
+	}
+
+	if !slices.Equal(TaintNames(1<<40|TaintWarn), []string{"warn", "taint_40"}) {
+		t.Errorf("unexpected taint names %v", TaintNames(1<<40|TaintWarn))
+	}
+
+	if !checkPath(t, TaintedPath) {
+		return
+	}
+
+	_, err = KernelTaint()
+	tErrorIf(t, err)
+}
@@ -0,0 +1,230 @@
+package sstat
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+)
+
+// PCIPath is the directory where the information for
+// PCI devices are located.
+const PCIPath string = "/sys/bus/pci/devices"
+
+// PCIDeviceInfo reports PCI device information. Documentation for the
+// object methods are taken from [sysfs-bus-pci].
+//
+// [sysfs-bus-pci]: https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-bus-pci
+type PCIDeviceInfo struct {
+	address         string
+	vendor, device  int
+	subsystemVendor int
+	subsystemDevice int
+	class           int
+	revision        int
+	driver          string
+	numaNode        int
+	vendorName      string
+	deviceName      string
+	className       string
+}
+
+// Address reports the address of the device in the
+// domain:bus:slot.function format, e.g. "0000:00:02.0".
+func (info *PCIDeviceInfo) Address() (value string) {
+	return info.address
+}
+
+// Vendor reports the vendor id of the device, e.g. 0x8086.
+func (info *PCIDeviceInfo) Vendor() (value int) {
+	return info.vendor
+}
+
+// Device reports the device id of the device.
+func (info *PCIDeviceInfo) Device() (value int) {
+	return info.device
+}
+
+// SubsystemVendor reports the vendor id of the subsystem of the device.
+func (info *PCIDeviceInfo) SubsystemVendor() (value int) {
+	return info.subsystemVendor
+}
+
+// SubsystemDevice reports the device id of the subsystem of the device.
+func (info *PCIDeviceInfo) SubsystemDevice() (value int) {
+	return info.subsystemDevice
+}
+
+// Class reports the 24-bit class code of the device, e.g. 0x030000
+// for a VGA compatible controller.
+func (info *PCIDeviceInfo) Class() (value int) {
+	return info.class
+}
+
+// BaseClass reports the base class of the device, e.g. 0x03 for a
+// display controller.
+func (info *PCIDeviceInfo) BaseClass() (value int) {
+	return info.class >> 16
+}
+
+// Subclass reports the subclass of the device.
+func (info *PCIDeviceInfo) Subclass() (value int) {
+	return info.class >> 8 & 0xff
+}
+
+// ProgIf reports the programming interface of the device.
+func (info *PCIDeviceInfo) ProgIf() (value int) {
+	return info.class & 0xff
+}
+
+// Revision reports the revision of the device.
+func (info *PCIDeviceInfo) Revision() (value int) {
+	return info.revision
+}
+
+// Driver reports the driver bound to the device, e.g. "i915",
+// and whether if a driver is bound to the device or not.
+func (info *PCIDeviceInfo) Driver() (value string, ok bool) {
+	return info.driver, info.driver != ""
+}
+
+// NumaNode reports the NUMA node of the device.
+// It is -1 if the device is not bound to a node.
+func (info *PCIDeviceInfo) NumaNode() (value int) {
+	return info.numaNode
+}
+
+// VendorName reports the name of the vendor from the ids database.
+// It is blank if the database was not supplied or lacks the vendor.
+func (info *PCIDeviceInfo) VendorName() (value string) {
+	return info.vendorName
+}
+
+// DeviceName reports the name of the device from the ids database.
+// It is blank if the database was not supplied or lacks the device.
+func (info *PCIDeviceInfo) DeviceName() (value string) {
+	return info.deviceName
+}
+
+// ClassName reports the name of the subclass, or the base class if the
+// subclass is unnamed, from the ids database. It is blank if the
+// database was not supplied or lacks the class.
+func (info *PCIDeviceInfo) ClassName() (value string) {
+	return info.className
+}
+
+func (info *PCIDeviceInfo) resolve(db *IdsDatabase) {
+	var ok bool
+
+	info.vendorName, _ = db.Vendor(info.vendor)
+	info.deviceName, _ = db.Device(info.vendor, info.device)
+
+	info.className, ok = db.Subclass(info.BaseClass(), info.Subclass())
+	if !ok {
+		info.className, _ = db.Class(info.BaseClass())
+	}
+}
+
+func pciDevice(dir, basepath string) (*PCIDeviceInfo, error) {
+	var (
+		pciInfo *PCIDeviceInfo
+		key     string
+		value   *int
+		str     string
+		err     error
+	)
+
+	pciInfo = &PCIDeviceInfo{
+		address:  basepath,
+		numaNode: -1,
+	}
+
+	for key, value = range map[string]*int{
+		"vendor": &pciInfo.vendor,
+		"device": &pciInfo.device,
+		"class":  &pciInfo.class,
+	} {
+		*value, err = pathReadHex(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for key, value = range map[string]*int{
+		"subsystem_vendor": &pciInfo.subsystemVendor,
+		"subsystem_device": &pciInfo.subsystemDevice,
+		"revision":         &pciInfo.revision,
+	} {
+		*value, err = pathReadHex(filepath.Join(dir, basepath, key))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	str, err = pathReadOptionalStr(filepath.Join(dir, basepath, "numa_node"))
+	if err != nil {
+		return nil, err
+	}
+
+	if str != "" {
+		pciInfo.numaNode, err = strconv.Atoi(str)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pciInfo.driver, err = pathDriver(filepath.Join(dir, basepath))
+	if err != nil {
+		return nil, err
+	}
+
+	return pciInfo, nil
+}
+
+func pciDevices(dir, glob string, db *IdsDatabase) ([]*PCIDeviceInfo, error) {
+	var (
+		pciPaths []string
+		pciInfos []*PCIDeviceInfo
+		idx      int
+		err      error
+	)
+
+	pciPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	pciInfos = make([]*PCIDeviceInfo, len(pciPaths))
+
+	for idx = range pciPaths {
+		pciInfos[idx], err = pciDevice(dir, filepath.Base(pciPaths[idx]))
+		if err != nil {
+			return nil, err
+		}
+
+		if db != nil {
+			pciInfos[idx].resolve(db)
+		}
+	}
+
+	return pciInfos, nil
+}
+
+// PCIDevices returns all PCI device information in [PCIPath] + glob.
+// If idsPath is not blank, the pci.ids database in idsPath, such as
+// [PCIIdsPath], is used to resolve the names of the devices.
+func PCIDevices(glob, idsPath string) ([]*PCIDeviceInfo, error) {
+	var (
+		db  *IdsDatabase
+		err error
+	)
+
+	if idsPath != "" {
+		db, err = LoadIds(idsPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pciDevices(PCIPath, glob, db)
+}
@@ -0,0 +1,25 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print every PCI device like lspci.
+func ExamplePCIDevices() {
+	var (
+		pciInfos []*sstat.PCIDeviceInfo
+		idx      int
+		err      error
+	)
+
+	pciInfos, err = sstat.PCIDevices("*", sstat.PCIIdsPath)
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range pciInfos {
+		fmt.Printf("%s %s: %s %s\n", pciInfos[idx].Address(), pciInfos[idx].ClassName(), pciInfos[idx].VendorName(), pciInfos[idx].DeviceName())
+	}
+}
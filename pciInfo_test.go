@@ -0,0 +1,84 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func pciTree(t *testing.T) string {
+	var root string
+
+	root = tmpTree(t, map[string]string{
+		"devices/0000:00:02.0/vendor":           "0x8086\n",
+		"devices/0000:00:02.0/device":           "0x9a49\n",
+		"devices/0000:00:02.0/class":            "0x030000\n",
+		"devices/0000:00:02.0/subsystem_vendor": "0x1028\n",
+		"devices/0000:00:02.0/subsystem_device": "0x0a21\n",
+		"devices/0000:00:02.0/revision":         "0x01\n",
+		"devices/0000:00:02.0/numa_node":        "-1\n",
+		"devices/0000:00:1f.4/vendor":           "0x8086\n",
+		"devices/0000:00:1f.4/device":           "0xa0a3\n",
+		"devices/0000:00:1f.4/class":            "0x0c0500\n",
+		"devices/0000:00:1f.4/revision":         "0x20\n",
+		"devices/0000:00:1f.4/numa_node":        "0\n",
+		"drivers/i915/bind":                     "",
+		"pci.ids":                               pciIdsFixture,
+	})
+
+	tErrorIf(t, os.Symlink(filepath.Join(root, "drivers", "i915"), filepath.Join(root, "devices", "0000:00:02.0", "driver")))
+
+	return root
+}
+
+func TestPCIDevices(t *testing.T) {
+	var (
+		root     string
+		db       *IdsDatabase
+		pciInfos []*PCIDeviceInfo
+		driver   string
+		ok       bool
+		err      error
+	)
+
+	root = pciTree(t)
+
+	db, err = LoadIds(filepath.Join(root, "pci.ids"))
+	tErrorIf(t, err)
+
+	pciInfos, err = pciDevices(filepath.Join(root, "devices"), "*", db)
+	tErrorIf(t, err)
+
+	if len(pciInfos) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(pciInfos))
+	}
+
+	driver, ok = pciInfos[0].Driver()
+	if !ok || driver != "i915" || pciInfos[0].Address() != "0000:00:02.0" || pciInfos[0].NumaNode() != -1 || pciInfos[0].SubsystemDevice() != 0x0a21 {
+		t.Errorf("unexpected graphics device %+v", pciInfos[0])
+	}
+
+	if pciInfos[0].BaseClass() != 0x03 || pciInfos[0].VendorName() != "Intel Corporation" || pciInfos[0].ClassName() != "VGA compatible controller" || !strings.Contains(pciInfos[0].DeviceName(), "Iris Xe") {
+		t.Errorf("unexpected graphics device names %+v", pciInfos[0])
+	}
+
+	_, ok = pciInfos[1].Driver()
+	if ok || pciInfos[1].Subclass() != 0x05 || pciInfos[1].Revision() != 0x20 || pciInfos[1].NumaNode() != 0 || pciInfos[1].DeviceName() != "" || pciInfos[1].ClassName() != "SMBus" {
+		t.Errorf("unexpected smbus device %+v", pciInfos[1])
+	}
+
+	pciInfos, err = pciDevices(filepath.Join(root, "devices"), "*", nil)
+	tErrorIf(t, err)
+
+	if pciInfos[0].VendorName() != "" {
+		t.Errorf("expected no names without ids database, got %q", pciInfos[0].VendorName())
+	}
+
+	if !checkPath(t, PCIPath) {
+		return
+	}
+
+	_, err = PCIDevices("*", "")
+	tErrorIf(t, err)
+}
@@ -81,9 +81,7 @@ func (info *PowerSupplyInfo) Name() (value string, ok bool) {
 	return info.Key("POWER_SUPPLY_NAME")
 }
 
-// PowerSupply returns power supply device information in
-// [PowerSupplyPath] + basepath.
-func PowerSupply(basepath string) (*PowerSupplyInfo, error) {
+func powerSupply(dir, basepath string) (*PowerSupplyInfo, error) {
 	var (
 		powerSupplyInfo *PowerSupplyInfo
 		err             error
@@ -93,7 +91,7 @@ func PowerSupply(basepath string) (*PowerSupplyInfo, error) {
 		info: make(map[string]string),
 	}
 
-	err = ScanFile(filepath.Join(PowerSupplyPath, basepath, "uevent"), bufio.ScanLines, func(text string) (bool, error) {
+	err = ScanFile(filepath.Join(dir, basepath, "uevent"), bufio.ScanLines, func(text string) (bool, error) {
 		var fields []string
 
 		fields = strings.Split(text, "=")
@@ -109,9 +107,13 @@ func PowerSupply(basepath string) (*PowerSupplyInfo, error) {
 	return powerSupplyInfo, err
 }
 
-// PowerSupplies returns all power supply device information in
-// [PowerSupplyPath] + glob.
-func PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
+// PowerSupply returns power supply device information in
+// [PowerSupplyPath] + basepath.
+func PowerSupply(basepath string) (*PowerSupplyInfo, error) {
+	return powerSupply(PowerSupplyPath, basepath)
+}
+
+func powerSupplies(dir, glob string) ([]*PowerSupplyInfo, error) {
 	var (
 		powerSupplyPaths []string
 		powerSupplyInfos []*PowerSupplyInfo
@@ -119,7 +121,7 @@ func PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
 		err              error
 	)
 
-	powerSupplyPaths, err = filepath.Glob(filepath.Join(PowerSupplyPath, glob))
+	powerSupplyPaths, err = filepath.Glob(filepath.Join(dir, glob))
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +129,7 @@ func PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
 	powerSupplyInfos = make([]*PowerSupplyInfo, len(powerSupplyPaths))
 
 	for idx = range powerSupplyPaths {
-		powerSupplyInfos[idx], err = PowerSupply(filepath.Base(powerSupplyPaths[idx]))
+		powerSupplyInfos[idx], err = powerSupply(dir, filepath.Base(powerSupplyPaths[idx]))
 		if err != nil {
 			return nil, err
 		}
@@ -135,3 +137,9 @@ func PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
 
 	return powerSupplyInfos, nil
 }
+
+// PowerSupplies returns all power supply device information in
+// [PowerSupplyPath] + glob.
+func PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
+	return powerSupplies(PowerSupplyPath, glob)
+}
@@ -0,0 +1,193 @@
+package sstat
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"unsafe"
+)
+
+// Counter is the set of integer types of counters.
+type Counter interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Keyer is a source of values by key, such as [*MemInfo] or [*SmapsInfo].
+type Keyer[T Counter] interface {
+	// Key reports the value of key and whether if the key is valid or not.
+	Key(key string) (value T, ok bool)
+}
+
+// CounterMap is a [Keyer] of a map of counters,
+// such as the fields of /proc/vmstat.
+type CounterMap[T Counter] map[string]T
+
+// Key reports the value of key and whether if the key is valid or not.
+func (counters CounterMap[T]) Key(key string) (value T, ok bool) {
+	value, ok = counters[key]
+
+	return value, ok
+}
+
+// RateSampler computes the per-second rates of counters of a [Keyer]
+// from its consecutive snapshots, along with their exponentially
+// weighted moving averages. It is not safe for concurrent use.
+type RateSampler[T Counter] struct {
+	keys     []string
+	width    uint
+	halfLife time.Duration
+	time     time.Time
+	sampled  bool
+	prev     map[string]T
+	rates    map[string]float64
+	averages map[string]float64
+	resets   map[string]bool
+}
+
+// NewRateSampler returns a [RateSampler] of the counters keys.
+//
+// The counters wrap around at width bits, with 0 meaning the size of
+// T. A counter decreasing by less than half of its range is taken to
+// have wrapped around, otherwise it is taken to have been reset, e.g.
+// by a reboot or a replugged device.
+//
+// The moving averages lose half of their weight every halfLife, so
+// that irregular intervals are weighted by their length. A halfLife of
+// 0 makes the averages follow the rates.
+func NewRateSampler[T Counter](width uint, halfLife time.Duration, keys ...string) *RateSampler[T] {
+	var zero T
+
+	if width == 0 || width > uint(unsafe.Sizeof(zero))*8 {
+		width = uint(unsafe.Sizeof(zero)) * 8
+	}
+
+	return &RateSampler[T]{
+		keys:     keys,
+		width:    width,
+		halfLife: halfLife,
+		prev:     make(map[string]T),
+		rates:    make(map[string]float64),
+		averages: make(map[string]float64),
+		resets:   make(map[string]bool),
+	}
+}
+
+// delta reports the increase of a counter from prev to cur
+// and whether if the counter was reset or not.
+func (sampler *RateSampler[T]) delta(prev, cur T) (value uint64, reset bool) {
+	var mask uint64
+
+	mask = math.MaxUint64 >> (64 - sampler.width)
+	value = (uint64(cur) - uint64(prev)) & mask
+
+	if cur >= prev {
+		return value, false
+	}
+
+	if value <= mask>>1 {
+		return value, false
+	}
+
+	return 0, true
+}
+
+// Update samples the counters of source at now, computing their rates
+// since the previous update. The first update of a counter, and the
+// first update after a reset, only record its value. Counters missing
+// from source lose their rate and average. It returns an error if now
+// is not after the previous update.
+func (sampler *RateSampler[T]) Update(now time.Time, source Keyer[T]) error {
+	var (
+		seconds, rate, weight float64
+		key                   string
+		prev, cur             T
+		delta                 uint64
+		ok, reset             bool
+	)
+
+	if sampler.sampled && !now.After(sampler.time) {
+		return fmt.Errorf("%v: update not after previous update at %v", now, sampler.time)
+	}
+
+	seconds = now.Sub(sampler.time).Seconds()
+
+	weight = 0
+	if sampler.halfLife > 0 {
+		weight = math.Exp2(-seconds / sampler.halfLife.Seconds())
+	}
+
+	for _, key = range sampler.keys {
+		cur, ok = source.Key(key)
+		if !ok {
+			delete(sampler.prev, key)
+			delete(sampler.rates, key)
+			delete(sampler.averages, key)
+			delete(sampler.resets, key)
+
+			continue
+		}
+
+		prev, ok = sampler.prev[key]
+		sampler.prev[key] = cur
+
+		if !ok {
+			continue
+		}
+
+		delta, reset = sampler.delta(prev, cur)
+		sampler.resets[key] = reset
+
+		if reset {
+			delete(sampler.rates, key)
+
+			continue
+		}
+
+		rate = float64(delta) / seconds
+		sampler.rates[key] = rate
+
+		_, ok = sampler.averages[key]
+		if !ok {
+			sampler.averages[key] = rate
+
+			continue
+		}
+
+		sampler.averages[key] = weight*sampler.averages[key] + (1-weight)*rate
+	}
+
+	sampler.time, sampler.sampled = now, true
+
+	return nil
+}
+
+// Time reports the time of the last update
+// and whether if there was an update or not.
+func (sampler *RateSampler[T]) Time() (value time.Time, ok bool) {
+	return sampler.time, sampler.sampled
+}
+
+// Rate reports the per-second rate of the counter key between the last
+// two updates and whether if the rate is known or not. The rate is
+// unknown before the second update of the counter, after its reset
+// and while it is missing.
+func (sampler *RateSampler[T]) Rate(key string) (value float64, ok bool) {
+	value, ok = sampler.rates[key]
+
+	return value, ok
+}
+
+// Average reports the exponentially weighted moving average of the
+// per-second rate of the counter key and whether if the average is
+// known or not. A reset keeps the average of the counter.
+func (sampler *RateSampler[T]) Average(key string) (value float64, ok bool) {
+	value, ok = sampler.averages[key]
+
+	return value, ok
+}
+
+// Reset reports whether if the counter key was reset at the last update.
+func (sampler *RateSampler[T]) Reset(key string) (value bool) {
+	return sampler.resets[key]
+}
@@ -0,0 +1,36 @@
+package sstat_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Compute the rate of page faults from two snapshots of /proc/vmstat.
+func ExampleRateSampler() {
+	var (
+		sampler       *sstat.RateSampler[uint64]
+		start         time.Time
+		rate, average float64
+		err           error
+	)
+
+	sampler = sstat.NewRateSampler[uint64](0, 10*time.Second, "pgfault", "pgmajfault")
+	start = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err = sampler.Update(start, sstat.CounterMap[uint64]{"pgfault": 1000, "pgmajfault": 10})
+	if err != nil {
+		panic(err)
+	}
+
+	err = sampler.Update(start.Add(2*time.Second), sstat.CounterMap[uint64]{"pgfault": 1500, "pgmajfault": 12})
+	if err != nil {
+		panic(err)
+	}
+
+	rate, _ = sampler.Rate("pgfault")
+	average, _ = sampler.Average("pgmajfault")
+	fmt.Printf("pgfault: %g/s, pgmajfault: %g/s\n", rate, average)
+	// Output: pgfault: 250/s, pgmajfault: 1/s
+}
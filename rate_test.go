@@ -0,0 +1,173 @@
+package sstat
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateSamplerDelta(t *testing.T) {
+	var (
+		tests = []struct {
+			name          string
+			width         uint
+			prev, cur     uint64
+			expected      uint64
+			expectedReset bool
+		}{
+			{"increase", 0, 100, 150, 50, false},
+			{"unchanged", 0, 100, 100, 0, false},
+			{"64-bit wraparound", 0, math.MaxUint64 - 9, 5, 15, false},
+			{"64-bit reset", 0, 1000, 5, 0, true},
+			{"32-bit wraparound", 32, math.MaxUint32 - 9, 5, 15, false},
+			{"32-bit reset", 32, 1 << 31, 5, 0, true},
+			{"32-bit wraparound at half the range", 32, 1<<31 + 10, 5, 1<<31 - 5, false},
+			{"8-bit wraparound", 8, 250, 4, 10, false},
+			{"8-bit reset", 8, 100, 4, 0, true},
+		}
+		sampler *RateSampler[uint64]
+		delta   uint64
+		reset   bool
+		idx     int
+	)
+
+	for idx = range tests {
+		sampler = NewRateSampler[uint64](tests[idx].width, 0)
+
+		delta, reset = sampler.delta(tests[idx].prev, tests[idx].cur)
+		if delta != tests[idx].expected || reset != tests[idx].expectedReset {
+			t.Errorf("%s: expected %d, %t, got %d, %t", tests[idx].name, tests[idx].expected, tests[idx].expectedReset, delta, reset)
+		}
+	}
+}
+
+func TestRateSamplerSigned(t *testing.T) {
+	var (
+		sampler *RateSampler[int8]
+		delta   uint64
+		reset   bool
+	)
+
+	sampler = NewRateSampler[int8](0, 0)
+
+	delta, reset = sampler.delta(math.MaxInt8, math.MinInt8)
+	if delta != 1 || reset {
+		t.Errorf("expected %d, %t, got %d, %t", 1, false, delta, reset)
+	}
+}
+
+func TestRateSampler(t *testing.T) {
+	type update struct {
+		name      string
+		seconds   int
+		counters  CounterMap[uint32]
+		rate      float64
+		rateOK    bool
+		average   float64
+		averageOK bool
+		reset     bool
+	}
+
+	var (
+		tests = []struct {
+			name     string
+			halfLife time.Duration
+			updates  []update
+		}{
+			{
+				name:     "no half-life",
+				halfLife: 0,
+				updates: []update{
+					{"first update", 0, CounterMap[uint32]{"pgfault": 100}, 0, false, 0, false, false},
+					{"second update", 2, CounterMap[uint32]{"pgfault": 300}, 100, true, 100, true, false},
+					{"unchanged", 3, CounterMap[uint32]{"pgfault": 300}, 0, true, 0, true, false},
+					{"reset", 5, CounterMap[uint32]{"pgfault": 7}, 0, false, 0, true, true},
+					{"after reset", 6, CounterMap[uint32]{"pgfault": 17}, 10, true, 10, true, false},
+					{"missing", 7, CounterMap[uint32]{}, 0, false, 0, false, false},
+					{"reappeared", 8, CounterMap[uint32]{"pgfault": 17}, 0, false, 0, false, false},
+					{"after reappearing", 9, CounterMap[uint32]{"pgfault": 27}, 10, true, 10, true, false},
+				},
+			},
+			{
+				name:     "2s half-life",
+				halfLife: 2 * time.Second,
+				updates: []update{
+					{"first update", 0, CounterMap[uint32]{"pgfault": math.MaxUint32 - 699}, 0, false, 0, false, false},
+					{"initial average", 2, CounterMap[uint32]{"pgfault": math.MaxUint32 - 499}, 100, true, 100, true, false},
+					{"one half-life", 4, CounterMap[uint32]{"pgfault": math.MaxUint32 - 99}, 200, true, 150, true, false},
+					{"wraparound over two half-lives", 8, CounterMap[uint32]{"pgfault": 700}, 200, true, 187.5, true, false},
+					{"reset keeps average", 9, CounterMap[uint32]{"pgfault": 5}, 0, false, 187.5, true, true},
+					{"after reset", 11, CounterMap[uint32]{"pgfault": 205}, 100, true, 143.75, true, false},
+				},
+			},
+		}
+		base     time.Time
+		sampler  *RateSampler[uint32]
+		expected update
+		rate     float64
+		average  float64
+		ok       bool
+		idx, jdx int
+		err      error
+	)
+
+	base = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for idx = range tests {
+		sampler = NewRateSampler[uint32](0, tests[idx].halfLife, "pgfault")
+
+		for jdx = range tests[idx].updates {
+			expected = tests[idx].updates[jdx]
+
+			err = sampler.Update(base.Add(time.Duration(expected.seconds)*time.Second), expected.counters)
+			tErrorIf(t, err)
+
+			rate, ok = sampler.Rate("pgfault")
+			if ok != expected.rateOK || math.Abs(rate-expected.rate) > 1e-9 {
+				t.Errorf("%s: %s: expected rate %g, %t, got %g, %t", tests[idx].name, expected.name, expected.rate, expected.rateOK, rate, ok)
+			}
+
+			average, ok = sampler.Average("pgfault")
+			if ok != expected.averageOK || math.Abs(average-expected.average) > 1e-9 {
+				t.Errorf("%s: %s: expected average %g, %t, got %g, %t", tests[idx].name, expected.name, expected.average, expected.averageOK, average, ok)
+			}
+
+			if sampler.Reset("pgfault") != expected.reset {
+				t.Errorf("%s: %s: expected reset %t, got %t", tests[idx].name, expected.name, expected.reset, sampler.Reset("pgfault"))
+			}
+		}
+	}
+}
+
+func TestRateSamplerUpdateTime(t *testing.T) {
+	var (
+		sampler *RateSampler[int]
+		base    time.Time
+		now     time.Time
+		ok      bool
+		err     error
+	)
+
+	base = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sampler = NewRateSampler[int](0, 0, "MemFree")
+
+	_, ok = sampler.Time()
+	if ok {
+		t.Error("expected no update time before the first update, got one")
+	}
+
+	err = sampler.Update(base, &MemInfo{info: map[string]int{"MemFree": 10}})
+	tErrorIf(t, err)
+
+	for _, now = range []time.Time{base, base.Add(-time.Second)} {
+		err = sampler.Update(now, &MemInfo{info: map[string]int{"MemFree": 20}})
+		if err == nil {
+			t.Errorf("expected an error for an update at %v after %v, got nil", now, base)
+		}
+	}
+
+	now, ok = sampler.Time()
+	if !ok || !now.Equal(base) {
+		t.Errorf("expected %v, %t, got %v, %t", base, true, now, ok)
+	}
+}
@@ -0,0 +1,529 @@
+package sstat
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RecordGlobs are the files recorded by [NewRecorder] when no globs
+// are given, which are every file read by [NewMemInfo],
+// [PowerSupplies], [Batteries], [Backlights] and [BacklightChans].
+var RecordGlobs = []string{
+	MemInfoPath,
+	filepath.Join(PowerSupplyPath, "*", "uevent"),
+	filepath.Join(BacklightPath, "*", "bl_power"),
+	filepath.Join(BacklightPath, "*", "brightness"),
+	filepath.Join(BacklightPath, "*", "actual_brightness"),
+	filepath.Join(BacklightPath, "*", "max_brightness"),
+	filepath.Join(BacklightPath, "*", "type"),
+}
+
+// Recorder records the raw contents of sysfs and procfs files over
+// time into a tar archive. Every call to [Recorder.Record] appends a
+// frame of every matching file, stored under its absolute path without
+// the leading slash and with the time of the frame as modification
+// time. Every frame starts with a directory entry of the root, so that
+// frames without any file are kept. The archive is replayed by
+// [NewReplayer].
+type Recorder struct {
+	root   string
+	globs  []string
+	writer *tar.Writer
+}
+
+// NewRecorder returns a [Recorder] writing the archive to writer.
+// If globs is empty, [RecordGlobs] is used.
+func NewRecorder(writer io.Writer, globs ...string) *Recorder {
+	if len(globs) == 0 {
+		globs = RecordGlobs
+	}
+
+	return &Recorder{
+		root:   "/",
+		globs:  globs,
+		writer: tar.NewWriter(writer),
+	}
+}
+
+// Record appends a frame of every file matching the globs of recorder
+// with the time now. Files that fail to be read are skipped, such as
+// files that vanish, require root or return EIO, ENODEV or ENODATA
+// like some power supply and backlight attributes, so they are missing
+// from the frame when replayed.
+func (recorder *Recorder) Record(now time.Time) error {
+	var (
+		paths   []string
+		matches []string
+		glob    string
+		path    string
+		name    string
+		buf     []byte
+		err     error
+	)
+
+	for _, glob = range recorder.globs {
+		matches, err = filepath.Glob(filepath.Join(recorder.root, glob))
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, matches...)
+	}
+
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	err = recorder.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     "./",
+		Mode:     0o755,
+		ModTime:  now,
+		Format:   tar.FormatPAX,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, path = range paths {
+		buf, err = os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		name, err = filepath.Rel(recorder.root, path)
+		if err != nil {
+			return err
+		}
+
+		err = recorder.writer.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     filepath.ToSlash(name),
+			Mode:     0o644,
+			Size:     int64(len(buf)),
+			ModTime:  now,
+			Format:   tar.FormatPAX,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = recorder.writer.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return recorder.writer.Flush()
+}
+
+// Run records a frame immediately and every interval until ctx is done.
+func (recorder *Recorder) Run(ctx context.Context, interval time.Duration) error {
+	var (
+		ticker *time.Ticker
+		now    time.Time
+		err    error
+	)
+
+	ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	now = time.Now()
+
+	for {
+		err = recorder.Record(now)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now = <-ticker.C:
+		}
+	}
+}
+
+// Close writes the end of the archive. It does not close
+// the writer passed to [NewRecorder].
+func (recorder *Recorder) Close() error {
+	return recorder.writer.Close()
+}
+
+// replayFrame is a single frame of a recording.
+type replayFrame struct {
+	time  time.Time
+	files map[string][]byte
+}
+
+// replayBacklight is a backlight watched with [Replayer.BacklightChans].
+type replayBacklight struct {
+	name          string
+	backlightChan chan *BacklightInfo
+	errChan       chan error
+}
+
+// Replayer replays an archive written by [Recorder] to a temporary
+// directory tree, one frame at a time in timestamp order. The readers
+// of the replayer, such as [Replayer.Batteries], read the tree instead
+// of the system, and watchers, such as [Replayer.BacklightChans],
+// receive the changes of every replayed frame.
+type Replayer struct {
+	root       string
+	frames     []*replayFrame
+	next       int
+	files      map[string][]byte
+	backlights []*replayBacklight
+}
+
+// NewReplayer reads the archive in reader and returns a [Replayer]
+// with the first frame applied. Call [Replayer.Close] to remove the
+// temporary directory tree.
+func NewReplayer(reader io.Reader) (*Replayer, error) {
+	var (
+		replayer  *Replayer
+		tarReader *tar.Reader
+		header    *tar.Header
+		frame     *replayFrame
+		name      string
+		buf       []byte
+		err       error
+	)
+
+	replayer = &Replayer{
+		files: make(map[string][]byte),
+	}
+
+	tarReader = tar.NewReader(reader)
+
+	for {
+		header, err = tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag == tar.TypeDir && path.Clean(header.Name) == "." {
+			frame = &replayFrame{
+				time:  header.ModTime,
+				files: make(map[string][]byte),
+			}
+
+			replayer.frames = append(replayer.frames, frame)
+
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name = filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("%s: invalid recorded path", header.Name)
+		}
+
+		buf, err = io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+
+		if frame == nil || !frame.time.Equal(header.ModTime) {
+			frame = &replayFrame{
+				time:  header.ModTime,
+				files: make(map[string][]byte),
+			}
+
+			replayer.frames = append(replayer.frames, frame)
+		}
+
+		frame.files[name] = buf
+	}
+
+	if len(replayer.frames) == 0 {
+		return nil, errors.New("empty recording")
+	}
+
+	slices.SortStableFunc(replayer.frames, func(a, b *replayFrame) int {
+		return a.time.Compare(b.time)
+	})
+
+	replayer.root, err = os.MkdirTemp("", "sstat-replay-")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = replayer.Next()
+	if err != nil {
+		os.RemoveAll(replayer.root)
+
+		return nil, err
+	}
+
+	return replayer, nil
+}
+
+// Root reports the directory where the frames are replayed.
+// The recorded files are found in their absolute path under it,
+// e.g. [Replayer.Root] + [MemInfoPath].
+func (replayer *Replayer) Root() (value string) {
+	return replayer.root
+}
+
+// Len reports the number of frames of the recording.
+func (replayer *Replayer) Len() (value int) {
+	return len(replayer.frames)
+}
+
+// Times reports the times of every frame of the recording in order.
+func (replayer *Replayer) Times() (value []time.Time) {
+	var idx int
+
+	value = make([]time.Time, len(replayer.frames))
+
+	for idx = range replayer.frames {
+		value[idx] = replayer.frames[idx].time
+	}
+
+	return value
+}
+
+// removeEmptyParents removes the empty parents of path within root.
+func removeEmptyParents(root, path string) {
+	for path = filepath.Dir(path); path != root && strings.HasPrefix(path, root); path = filepath.Dir(path) {
+		if os.Remove(path) != nil {
+			return
+		}
+	}
+}
+
+// Next applies the next frame to the tree and reports its time. Only
+// the files that changed since the previous frame are written, and
+// files missing from the frame are removed. The backlights watched with
+// [Replayer.BacklightChans] that changed are then sent to their
+// channels, so Next blocks until the previous value of every changed
+// backlight is received. It returns [io.EOF] after the last frame.
+func (replayer *Replayer) Next() (time.Time, error) {
+	var (
+		frame   *replayFrame
+		changed []string
+		name    string
+		buf     []byte
+		old     []byte
+		ok      bool
+		path    string
+		err     error
+	)
+
+	if replayer.next == len(replayer.frames) {
+		return time.Time{}, io.EOF
+	}
+
+	frame = replayer.frames[replayer.next]
+	replayer.next++
+
+	for name = range replayer.files {
+		_, ok = frame.files[name]
+		if ok {
+			continue
+		}
+
+		path = filepath.Join(replayer.root, name)
+
+		err = os.Remove(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		removeEmptyParents(replayer.root, path)
+		delete(replayer.files, name)
+
+		changed = append(changed, name)
+	}
+
+	for name, buf = range frame.files {
+		old, ok = replayer.files[name]
+		if ok && bytes.Equal(old, buf) {
+			continue
+		}
+
+		path = filepath.Join(replayer.root, name)
+
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		err = os.WriteFile(path, buf, 0o644)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		replayer.files[name] = buf
+
+		changed = append(changed, name)
+	}
+
+	err = replayer.notifyBacklights(changed)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return frame.time, nil
+}
+
+// notifyBacklights sends the watched backlights
+// with a file in changed to their channels.
+func (replayer *Replayer) notifyBacklights(changed []string) error {
+	var (
+		watched       *replayBacklight
+		backlightInfo *BacklightInfo
+		prefix        string
+		name          string
+		err           error
+	)
+
+	for _, watched = range replayer.backlights {
+		prefix = filepath.Join(strings.TrimPrefix(BacklightPath, "/"), watched.name) + string(filepath.Separator)
+
+		for _, name = range changed {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+
+			backlightInfo, err = backlight(filepath.Join(replayer.root, BacklightPath), watched.name)
+			if err != nil {
+				select {
+				case watched.errChan <- err:
+				default:
+				}
+
+				return err
+			}
+
+			watched.backlightChan <- backlightInfo
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// Play applies the remaining frames until the end of the recording or
+// until ctx is done, waiting between frames for the time between them
+// divided by speed. A speed of 0 or less applies the frames without
+// waiting.
+func (replayer *Replayer) Play(ctx context.Context, speed float64) error {
+	var (
+		timer     *time.Timer
+		prev, now time.Time
+		err       error
+	)
+
+	if replayer.next != 0 {
+		prev = replayer.frames[replayer.next-1].time
+	}
+
+	for replayer.next != len(replayer.frames) {
+		now = replayer.frames[replayer.next].time
+
+		if speed > 0 && !prev.IsZero() {
+			timer = time.NewTimer(time.Duration(float64(now.Sub(prev)) / speed))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		prev, err = replayer.Next()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close removes the directory tree of replayer.
+func (replayer *Replayer) Close() error {
+	return os.RemoveAll(replayer.root)
+}
+
+// MemInfo returns the replayed memory usage information,
+// see [NewMemInfo].
+func (replayer *Replayer) MemInfo() (*MemInfo, error) {
+	return newMemInfo(filepath.Join(replayer.root, MemInfoPath))
+}
+
+// PowerSupplies returns the replayed power supply device
+// information in glob, see [PowerSupplies].
+func (replayer *Replayer) PowerSupplies(glob string) ([]*PowerSupplyInfo, error) {
+	return powerSupplies(filepath.Join(replayer.root, PowerSupplyPath), glob)
+}
+
+// Batteries returns the replayed batteries, see [Batteries].
+func (replayer *Replayer) Batteries() ([]*BatteryInfo, error) {
+	return batteries(filepath.Join(replayer.root, PowerSupplyPath))
+}
+
+// Backlights returns the replayed backlight information in glob,
+// see [Backlights]. The connector of the backlights is not recorded.
+func (replayer *Replayer) Backlights(glob string) ([]*BacklightInfo, error) {
+	return backlights(filepath.Join(replayer.root, BacklightPath), glob)
+}
+
+// BacklightChans returns a map of channels that sends the replayed
+// backlight information for each backlight in glob, see [BacklightChans].
+// The current backlight information is sent first, followed by the
+// information of every frame applied by [Replayer.Next] that changes
+// the backlight. Errors reading a backlight are sent to the error
+// channel and returned by [Replayer.Next].
+func (replayer *Replayer) BacklightChans(glob string) (map[string]<-chan *BacklightInfo, <-chan error, error) {
+	var (
+		backlightChans map[string]<-chan *BacklightInfo
+		backlightInfos []*BacklightInfo
+		watched        *replayBacklight
+		errChan        chan error
+		idx            int
+		err            error
+	)
+
+	backlightInfos, err = replayer.Backlights(glob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backlightChans = make(map[string]<-chan *BacklightInfo)
+	errChan = make(chan error, 1)
+
+	for idx = range backlightInfos {
+		watched = &replayBacklight{
+			name:          backlightInfos[idx].Name(),
+			backlightChan: make(chan *BacklightInfo, 1),
+			errChan:       errChan,
+		}
+
+		watched.backlightChan <- backlightInfos[idx]
+		backlightChans[watched.name] = watched.backlightChan
+		replayer.backlights = append(replayer.backlights, watched)
+	}
+
+	return backlightChans, errChan, nil
+}
@@ -0,0 +1,79 @@
+package sstat_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Record the batteries, backlights and memory every 5 seconds
+// until interrupted.
+func ExampleRecorder_Run() {
+	var (
+		file     *os.File
+		recorder *sstat.Recorder
+		ctx      context.Context
+		stop     context.CancelFunc
+		err      error
+	)
+
+	file, err = os.Create("sstat.tar")
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	recorder = sstat.NewRecorder(file)
+	defer recorder.Close()
+
+	ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	recorder.Run(ctx, 5*time.Second)
+}
+
+// Print the battery capacity of every frame of a recording.
+func ExampleReplayer_Next() {
+	var (
+		file         *os.File
+		replayer     *sstat.Replayer
+		batteryInfos []*sstat.BatteryInfo
+		batteryInfo  *sstat.BatteryInfo
+		now          time.Time
+		capacity     string
+		err          error
+	)
+
+	file, err = os.Open("sstat.tar")
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	replayer, err = sstat.NewReplayer(file)
+	if err != nil {
+		panic(err)
+	}
+
+	defer replayer.Close()
+
+	for _, now = range replayer.Times() {
+		batteryInfos, err = replayer.Batteries()
+		if err != nil {
+			panic(err)
+		}
+
+		for _, batteryInfo = range batteryInfos {
+			capacity, _ = batteryInfo.Capacity()
+			fmt.Printf("%s: %s%%\n", now.Format(time.TimeOnly), capacity)
+		}
+
+		replayer.Next()
+	}
+}
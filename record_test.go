@@ -0,0 +1,281 @@
+package sstat
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func recordFixture(t *testing.T) []byte {
+	var (
+		buf      bytes.Buffer
+		recorder *Recorder
+		root     string
+		start    time.Time
+		frame    map[string]string
+		name     string
+		content  string
+		idx      int
+		err      error
+	)
+
+	root = t.TempDir()
+	start = time.Date(2024, 1, 2, 3, 4, 5, 250000000, time.UTC)
+
+	recorder = NewRecorder(&buf)
+	recorder.root = root
+
+	for idx, frame = range []map[string]string{
+		{
+			"proc/meminfo":                                          "MemTotal:       16309412 kB\nMemAvailable:    9861240 kB\n",
+			"sys/class/power_supply/BAT0/uevent":                    "POWER_SUPPLY_NAME=BAT0\nPOWER_SUPPLY_CAPACITY=90\n",
+			"sys/class/backlight/intel_backlight/bl_power":          "0\n",
+			"sys/class/backlight/intel_backlight/brightness":        "100\n",
+			"sys/class/backlight/intel_backlight/actual_brightness": "100\n",
+			"sys/class/backlight/intel_backlight/max_brightness":    "1000\n",
+			"sys/class/backlight/intel_backlight/type":              "raw\n",
+		},
+		{
+			"sys/class/power_supply/BAT0/uevent":             "POWER_SUPPLY_NAME=BAT0\nPOWER_SUPPLY_CAPACITY=89\n",
+			"sys/class/backlight/intel_backlight/brightness": "50\n",
+		},
+		{
+			"sys/class/power_supply/BAT0/uevent": "",
+		},
+	} {
+		for name, content = range frame {
+			if content == "" {
+				err = os.RemoveAll(filepath.Dir(filepath.Join(root, name)))
+				tErrorIf(t, err)
+
+				continue
+			}
+
+			err = os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0o755)
+			tErrorIf(t, err)
+
+			err = os.WriteFile(filepath.Join(root, name), []byte(content), 0o644)
+			tErrorIf(t, err)
+		}
+
+		err = recorder.Record(start.Add(time.Duration(idx) * 30 * time.Second))
+		tErrorIf(t, err)
+	}
+
+	err = recorder.Close()
+	tErrorIf(t, err)
+
+	return buf.Bytes()
+}
+
+func TestReplayer(t *testing.T) {
+	var (
+		replayer       *Replayer
+		times          []time.Time
+		now            time.Time
+		memInfo        *MemInfo
+		batteryInfos   []*BatteryInfo
+		backlightChans map[string]<-chan *BacklightInfo
+		backlightInfo  *BacklightInfo
+		capacity       string
+		memTotal       int
+		err            error
+	)
+
+	replayer, err = NewReplayer(bytes.NewReader(recordFixture(t)))
+	tErrorIf(t, err)
+
+	defer replayer.Close()
+
+	times = replayer.Times()
+	if replayer.Len() != 3 || !times[0].Equal(time.Date(2024, 1, 2, 3, 4, 5, 250000000, time.UTC)) || times[2].Sub(times[1]) != 30*time.Second {
+		t.Fatalf("Replayer frames at %v", times)
+	}
+
+	memInfo, err = replayer.MemInfo()
+	tErrorIf(t, err)
+
+	memTotal, _ = memInfo.MemTotal()
+	if memTotal != 16309412 {
+		t.Errorf("MemTotal = %d, want 16309412", memTotal)
+	}
+
+	batteryInfos, err = replayer.Batteries()
+	tErrorIf(t, err)
+
+	if len(batteryInfos) != 1 {
+		t.Fatalf("Batteries() reported %d batteries, want 1", len(batteryInfos))
+	}
+
+	capacity, _ = batteryInfos[0].Capacity()
+	if capacity != "90" {
+		t.Errorf("Capacity = %s, want 90", capacity)
+	}
+
+	backlightChans, _, err = replayer.BacklightChans("*")
+	tErrorIf(t, err)
+
+	backlightInfo = <-backlightChans["intel_backlight"]
+	if backlightInfo.Brightness() != 100 || backlightInfo.MaxBrightness() != 1000 || backlightInfo.Type() != "raw" {
+		t.Errorf("BacklightChans initial = %+v", backlightInfo)
+	}
+
+	now, err = replayer.Next()
+	tErrorIf(t, err)
+
+	if !now.Equal(times[1]) {
+		t.Errorf("Next() = %v, want %v", now, times[1])
+	}
+
+	backlightInfo = <-backlightChans["intel_backlight"]
+	if backlightInfo.Brightness() != 50 {
+		t.Errorf("BacklightChans brightness = %d, want 50", backlightInfo.Brightness())
+	}
+
+	batteryInfos, err = replayer.Batteries()
+	tErrorIf(t, err)
+
+	capacity, _ = batteryInfos[0].Capacity()
+	if capacity != "89" {
+		t.Errorf("Capacity = %s, want 89", capacity)
+	}
+
+	err = replayer.Play(context.Background(), 0)
+	tErrorIf(t, err)
+
+	select {
+	case backlightInfo = <-backlightChans["intel_backlight"]:
+		t.Errorf("BacklightChans sent unchanged backlight %+v", backlightInfo)
+	default:
+	}
+
+	batteryInfos, err = replayer.Batteries()
+	tErrorIf(t, err)
+
+	if len(batteryInfos) != 0 {
+		t.Errorf("Batteries() reported %d removed batteries", len(batteryInfos))
+	}
+
+	_, err = replayer.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Next() after the last frame = %v, want io.EOF", err)
+	}
+}
+
+func TestReplayerPlay(t *testing.T) {
+	var (
+		replayer *Replayer
+		ctx      context.Context
+		cancel   context.CancelFunc
+		err      error
+	)
+
+	replayer, err = NewReplayer(bytes.NewReader(recordFixture(t)))
+	tErrorIf(t, err)
+
+	defer replayer.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = replayer.Play(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Play() of 30s frames at speed 1 = %v, want deadline exceeded", err)
+	}
+
+	err = replayer.Play(context.Background(), 1e6)
+	tErrorIf(t, err)
+}
+
+func TestRecorderSkip(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		recorder *Recorder
+		replayer *Replayer
+		root     string
+		memInfo  *MemInfo
+		memTotal int
+		err      error
+	)
+
+	// A directory fails to be read like an attribute returning EIO.
+	root = tmpTree(t, map[string]string{
+		"proc/meminfo": "MemTotal:       16309412 kB\n",
+		"sys/class/power_supply/BAT0/uevent/.keep": "",
+	})
+
+	recorder = NewRecorder(&buf)
+	recorder.root = root
+
+	err = recorder.Record(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	tErrorIf(t, err)
+
+	tErrorIf(t, os.Remove(filepath.Join(root, "proc", "meminfo")))
+
+	err = recorder.Record(time.Date(2024, 1, 2, 3, 4, 35, 0, time.UTC))
+	tErrorIf(t, err)
+
+	err = recorder.Close()
+	tErrorIf(t, err)
+
+	replayer, err = NewReplayer(&buf)
+	tErrorIf(t, err)
+
+	defer replayer.Close()
+
+	if replayer.Len() != 2 {
+		t.Fatalf("expected %d frames, got %d", 2, replayer.Len())
+	}
+
+	memInfo, err = replayer.MemInfo()
+	tErrorIf(t, err)
+
+	memTotal, _ = memInfo.MemTotal()
+	if memTotal != 16309412 {
+		t.Errorf("expected %d, got %d", 16309412, memTotal)
+	}
+
+	_, err = replayer.Next()
+	tErrorIf(t, err)
+
+	_, err = replayer.MemInfo()
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the empty frame to remove %s, got %v", MemInfoPath, err)
+	}
+}
+
+func TestNewReplayerInvalid(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		writer *tar.Writer
+		err    error
+	)
+
+	_, err = NewReplayer(&buf)
+	if err == nil {
+		t.Error("NewReplayer accepted an empty recording")
+	}
+
+	writer = tar.NewWriter(&buf)
+
+	err = writer.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../etc/passwd", Mode: 0o644, Size: 1})
+	tErrorIf(t, err)
+
+	_, err = writer.Write([]byte("x"))
+	tErrorIf(t, err)
+
+	err = writer.Close()
+	tErrorIf(t, err)
+
+	_, err = NewReplayer(&buf)
+	if err == nil {
+		t.Error("NewReplayer accepted a path outside of the tree")
+	}
+}
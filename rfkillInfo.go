@@ -0,0 +1,428 @@
+package sstat
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RfkillPath is the directory where the information for
+// rfkill switches are located.
+const RfkillPath string = "/sys/class/rfkill"
+
+// RfkillDevPath is the path to the device that
+// reports rfkill events.
+const RfkillDevPath string = "/dev/rfkill"
+
+// RfkillPollInterval is the interval at which [RfkillPath] is polled
+// by [RfkillEvents] when [RfkillDevPath] cannot be opened.
+const RfkillPollInterval time.Duration = 2 * time.Second
+
+// Valid values of [RfkillEvent.Op] as defined in linux/rfkill.h.
+const (
+	RfkillOpAdd = iota
+	RfkillOpDel
+	RfkillOpChange
+	RfkillOpChangeAll
+)
+
+// rfkillTypes are the names of enum rfkill_type as
+// reported in the type file of [RfkillPath].
+var rfkillTypes = []string{
+	"all",
+	"wlan",
+	"bluetooth",
+	"uwb",
+	"wimax",
+	"wwan",
+	"gps",
+	"fm",
+	"nfc",
+}
+
+// RfkillInfo reports rfkill switch information. Documentation for the
+// object methods are taken from [sysfs-class-rfkill].
+//
+// [sysfs-class-rfkill]: https://www.kernel.org/doc/Documentation/ABI/stable/sysfs-class-rfkill
+type RfkillInfo struct {
+	index int
+	typ   string
+	name  string
+	soft  bool
+	hard  bool
+	state int
+}
+
+// Index reports the index of the switch, as used by [RfkillEvent.Index].
+func (info *RfkillInfo) Index() (value int) {
+	return info.index
+}
+
+// Type reports the driver type of the switch.
+//
+// Valid values are:
+//   - "wlan"
+//   - "bluetooth"
+//   - "uwb"
+//   - "wimax"
+//   - "wwan"
+//   - "gps"
+//   - "fm"
+//   - "nfc"
+func (info *RfkillInfo) Type() (value string) {
+	return info.typ
+}
+
+// Name reports the name assigned by the driver, e.g. "phy0" or "hci0".
+func (info *RfkillInfo) Name() (value string) {
+	return info.name
+}
+
+// Soft reports whether the radio is blocked by software,
+// e.g. by airplane mode.
+func (info *RfkillInfo) Soft() bool {
+	return info.soft
+}
+
+// Hard reports whether the radio is blocked by hardware,
+// e.g. by a physical switch.
+func (info *RfkillInfo) Hard() bool {
+	return info.hard
+}
+
+// Blocked reports whether the radio is blocked by software or hardware.
+func (info *RfkillInfo) Blocked() bool {
+	return info.soft || info.hard
+}
+
+// State reports the current state of the transmitter.
+//
+// Valid values are:
+//   - 0 : transmitter is turned off by software
+//   - 1 : transmitter is (potentially) active
+//   - 2 : transmitter is forced off by something outside of the driver's control
+func (info *RfkillInfo) State() (value int) {
+	return info.state
+}
+
+func rfkill(dir, basepath string) (*RfkillInfo, error) {
+	var (
+		rfkillInfo *RfkillInfo
+		key        string
+		value      *bool
+		num        int
+		err        error
+	)
+
+	rfkillInfo = new(RfkillInfo)
+
+	rfkillInfo.index, err = PathReadInt(filepath.Join(dir, basepath, "index"))
+	if err != nil {
+		return nil, err
+	}
+
+	rfkillInfo.state, err = PathReadInt(filepath.Join(dir, basepath, "state"))
+	if err != nil {
+		return nil, err
+	}
+
+	rfkillInfo.typ, err = PathReadStr(filepath.Join(dir, basepath, "type"))
+	if err != nil {
+		return nil, err
+	}
+
+	rfkillInfo.name, err = PathReadStr(filepath.Join(dir, basepath, "name"))
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value = range map[string]*bool{
+		"soft": &rfkillInfo.soft,
+		"hard": &rfkillInfo.hard,
+	} {
+		num, err = PathReadInt(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+
+		*value = num != 0
+	}
+
+	return rfkillInfo, nil
+}
+
+func rfkills(dir, glob string) ([]*RfkillInfo, error) {
+	var (
+		rfkillPaths []string
+		rfkillInfos []*RfkillInfo
+		idx         int
+		err         error
+	)
+
+	rfkillPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	rfkillInfos = make([]*RfkillInfo, len(rfkillPaths))
+
+	for idx = range rfkillPaths {
+		rfkillInfos[idx], err = rfkill(dir, filepath.Base(rfkillPaths[idx]))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rfkillInfos, nil
+}
+
+// Rfkill returns rfkill switch information in [RfkillPath] + basepath.
+func Rfkill(basepath string) (*RfkillInfo, error) {
+	return rfkill(RfkillPath, basepath)
+}
+
+// Rfkills returns all rfkill switch information in [RfkillPath] + glob.
+func Rfkills(glob string) ([]*RfkillInfo, error) {
+	return rfkills(RfkillPath, glob)
+}
+
+// RfkillEvent reports a change of an rfkill switch.
+type RfkillEvent struct {
+	index int
+	typ   string
+	op    int
+	soft  bool
+	hard  bool
+}
+
+// Index reports the index of the switch, as used by [RfkillInfo.Index].
+func (event *RfkillEvent) Index() (value int) {
+	return event.index
+}
+
+// Type reports the driver type of the switch, see [RfkillInfo.Type].
+func (event *RfkillEvent) Type() (value string) {
+	return event.typ
+}
+
+// Op reports the operation of the event.
+//
+// Valid values are:
+//   - [RfkillOpAdd]       : a switch was added
+//   - [RfkillOpDel]       : a switch was removed
+//   - [RfkillOpChange]    : the state of a switch changed
+//   - [RfkillOpChangeAll] : the state of every switch of a type changed
+func (event *RfkillEvent) Op() (value int) {
+	return event.op
+}
+
+// Soft reports whether the radio is blocked by software.
+func (event *RfkillEvent) Soft() bool {
+	return event.soft
+}
+
+// Hard reports whether the radio is blocked by hardware.
+func (event *RfkillEvent) Hard() bool {
+	return event.hard
+}
+
+// String formats the event like "rfkill event" of rfkill(8), e.g.
+// "idx 0 type wlan op change soft 1 hard 0".
+func (event *RfkillEvent) String() string {
+	var op string
+
+	op = "unknown"
+	if event.op >= RfkillOpAdd && event.op <= RfkillOpChangeAll {
+		op = []string{"add", "del", "change", "change-all"}[event.op]
+	}
+
+	return fmt.Sprintf("idx %d type %s op %s soft %d hard %d", event.index, event.typ, op, boolInt(event.soft), boolInt(event.hard))
+}
+
+// RfkillEventSize is the size of struct rfkill_event. Newer kernels
+// append fields to the struct, which are truncated by reading
+// [RfkillEventSize] bytes at a time.
+const RfkillEventSize int = 8
+
+// ParseRfkillEvent decodes a struct rfkill_event as read from [RfkillDevPath].
+func ParseRfkillEvent(buf []byte) (*RfkillEvent, error) {
+	var event *RfkillEvent
+
+	if len(buf) < RfkillEventSize {
+		return nil, errors.New("truncated rfkill event")
+	}
+
+	event = &RfkillEvent{
+		index: int(binary.NativeEndian.Uint32(buf[0:4])),
+		typ:   "unknown",
+		op:    int(buf[5]),
+		soft:  buf[6] != 0,
+		hard:  buf[7] != 0,
+	}
+
+	if int(buf[4]) < len(rfkillTypes) {
+		event.typ = rfkillTypes[buf[4]]
+	}
+
+	return event, nil
+}
+
+func readRfkillEvents(ctx context.Context, reader io.Reader, eventChan chan<- *RfkillEvent, errChan chan<- error) {
+	var (
+		buf   []byte
+		event *RfkillEvent
+		err   error
+	)
+
+	buf = make([]byte, RfkillEventSize)
+
+	for {
+		_, err = io.ReadFull(reader, buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				send(ctx, errChan, err)
+			}
+
+			return
+		}
+
+		event, err = ParseRfkillEvent(buf)
+		if err != nil {
+			send(ctx, errChan, err)
+
+			return
+		}
+
+		if !send(ctx, eventChan, event) {
+			return
+		}
+	}
+}
+
+func rfkillEventOf(info *RfkillInfo, op int) *RfkillEvent {
+	return &RfkillEvent{
+		index: info.index,
+		typ:   info.typ,
+		op:    op,
+		soft:  info.soft,
+		hard:  info.hard,
+	}
+}
+
+func pollRfkill(ctx context.Context, dir string, interval time.Duration, eventChan chan<- *RfkillEvent, errChan chan<- error) {
+	var (
+		ticker           *time.Ticker
+		rfkillInfos      []*RfkillInfo
+		known, current   map[int]*RfkillInfo
+		rfkillInfo, prev *RfkillInfo
+		index            int
+		ok               bool
+		err              error
+	)
+
+	ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	known = make(map[int]*RfkillInfo)
+
+	for {
+		rfkillInfos, err = rfkills(dir, "rfkill*")
+		if err != nil {
+			send(ctx, errChan, err)
+
+			return
+		}
+
+		current = make(map[int]*RfkillInfo)
+
+		for _, rfkillInfo = range rfkillInfos {
+			current[rfkillInfo.index] = rfkillInfo
+
+			prev, ok = known[rfkillInfo.index]
+
+			switch {
+			case !ok:
+				ok = send(ctx, eventChan, rfkillEventOf(rfkillInfo, RfkillOpAdd))
+			case prev.soft != rfkillInfo.soft || prev.hard != rfkillInfo.hard:
+				ok = send(ctx, eventChan, rfkillEventOf(rfkillInfo, RfkillOpChange))
+			}
+
+			if !ok {
+				return
+			}
+		}
+
+		for index, prev = range known {
+			_, ok = current[index]
+			if !ok && !send(ctx, eventChan, rfkillEventOf(prev, RfkillOpDel)) {
+				return
+			}
+		}
+
+		known = current
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RfkillEvents returns a channel that sends an [RfkillOpAdd] event for
+// every existing switch followed by an event at every change until ctx
+// is done. Events are read from [RfkillDevPath], which is closed once
+// ctx is done. If it cannot be opened, [RfkillPath] is polled every
+// [RfkillPollInterval] instead.
+func RfkillEvents(ctx context.Context) (<-chan *RfkillEvent, <-chan error, error) {
+	var (
+		eventChan chan *RfkillEvent
+		errChan   chan error
+		file      *os.File
+		err       error
+	)
+
+	eventChan = make(chan *RfkillEvent)
+	errChan = make(chan error)
+
+	file, err = os.Open(RfkillDevPath)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		go pollRfkill(ctx, RfkillPath, RfkillPollInterval, eventChan, errChan)
+
+		return eventChan, errChan, nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go func() {
+		var stop func() bool
+
+		stop = context.AfterFunc(ctx, func() {
+			file.Close()
+		})
+
+		readRfkillEvents(ctx, file, eventChan, errChan)
+
+		if stop() {
+			file.Close()
+		}
+	}()
+
+	return eventChan, errChan, nil
+}
+
+func boolInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,50 @@
+package sstat_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print whether each wireless radio is blocked.
+func ExampleRfkills() {
+	var (
+		rfkillInfos []*sstat.RfkillInfo
+		idx         int
+		err         error
+	)
+
+	rfkillInfos, err = sstat.Rfkills("rfkill*")
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range rfkillInfos {
+		fmt.Printf("%s (%s): blocked %t\n", rfkillInfos[idx].Name(), rfkillInfos[idx].Type(), rfkillInfos[idx].Blocked())
+	}
+}
+
+// Print every rfkill event, such as airplane mode being toggled.
+func ExampleRfkillEvents() {
+	var (
+		eventChan <-chan *sstat.RfkillEvent
+		event     *sstat.RfkillEvent
+		errChan   <-chan error
+		err       error
+	)
+
+	eventChan, errChan, err = sstat.RfkillEvents(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		select {
+		case event = <-eventChan:
+			fmt.Println(event)
+		case err = <-errChan:
+			panic(err)
+		}
+	}
+}
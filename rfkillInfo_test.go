@@ -0,0 +1,223 @@
+package sstat
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func rfkillTree(t *testing.T) string {
+	return tmpTree(t, map[string]string{
+		"rfkill0/index": "0\n",
+		"rfkill0/type":  "wlan\n",
+		"rfkill0/name":  "phy0\n",
+		"rfkill0/soft":  "0\n",
+		"rfkill0/hard":  "0\n",
+		"rfkill0/state": "1\n",
+		"rfkill1/index": "1\n",
+		"rfkill1/type":  "bluetooth\n",
+		"rfkill1/name":  "hci0\n",
+		"rfkill1/soft":  "1\n",
+		"rfkill1/hard":  "0\n",
+		"rfkill1/state": "0\n",
+	})
+}
+
+func TestRfkills(t *testing.T) {
+	var (
+		rfkillInfos []*RfkillInfo
+		err         error
+	)
+
+	rfkillInfos, err = rfkills(rfkillTree(t), "rfkill*")
+	tErrorIf(t, err)
+
+	if len(rfkillInfos) != 2 {
+		t.Fatalf("expected 2 switches, got %d", len(rfkillInfos))
+	}
+
+	if rfkillInfos[0].Type() != "wlan" || rfkillInfos[0].Name() != "phy0" || rfkillInfos[0].Blocked() || rfkillInfos[0].State() != 1 {
+		t.Errorf("unexpected wlan switch %+v", rfkillInfos[0])
+	}
+
+	if rfkillInfos[1].Type() != "bluetooth" || rfkillInfos[1].Index() != 1 || !rfkillInfos[1].Soft() || rfkillInfos[1].Hard() || rfkillInfos[1].State() != 0 {
+		t.Errorf("unexpected bluetooth switch %+v", rfkillInfos[1])
+	}
+
+	if !checkPath(t, RfkillPath) {
+		return
+	}
+
+	_, err = Rfkills("rfkill*")
+	tErrorIf(t, err)
+}
+
+func TestParseRfkillEvent(t *testing.T) {
+	var (
+		event *RfkillEvent
+		err   error
+	)
+
+	event, err = ParseRfkillEvent([]byte{3, 0, 0, 0, 2, 2, 1, 0, 0})
+	tErrorIf(t, err)
+
+	if event.Index() != 3 || event.Type() != "bluetooth" || event.Op() != RfkillOpChange || !event.Soft() || event.Hard() {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	if event.String() != "idx 3 type bluetooth op change soft 1 hard 0" {
+		t.Errorf("unexpected event string %q", event.String())
+	}
+
+	event, err = ParseRfkillEvent([]byte{0, 0, 0, 0, 42, 0, 0, 1})
+	tErrorIf(t, err)
+
+	if event.Type() != "unknown" || event.Op() != RfkillOpAdd || !event.Hard() {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	_, err = ParseRfkillEvent([]byte{0, 0, 0, 0, 1})
+	if err == nil {
+		t.Error("expected error for truncated event")
+	}
+}
+
+func TestReadRfkillEvents(t *testing.T) {
+	var (
+		eventChan chan *RfkillEvent
+		errChan   chan error
+		event     *RfkillEvent
+		want      string
+		err       error
+	)
+
+	eventChan = make(chan *RfkillEvent)
+	errChan = make(chan error)
+
+	go readRfkillEvents(context.Background(), bytes.NewReader([]byte{
+		0, 0, 0, 0, 1, 0, 0, 0,
+		1, 0, 0, 0, 2, 0, 1, 0,
+	}), eventChan, errChan)
+
+	for _, want = range []string{
+		"idx 0 type wlan op add soft 0 hard 0",
+		"idx 1 type bluetooth op add soft 1 hard 0",
+	} {
+		select {
+		case event = <-eventChan:
+		case err = <-errChan:
+			t.Fatal(err)
+		}
+
+		if event.String() != want {
+			t.Errorf("expected %q, got %q", want, event.String())
+		}
+	}
+
+	err = <-errChan
+	if err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestPollRfkill(t *testing.T) {
+	var (
+		root      string
+		eventChan chan *RfkillEvent
+		errChan   chan error
+		event     *RfkillEvent
+		err       error
+	)
+
+	root = rfkillTree(t)
+	eventChan = make(chan *RfkillEvent)
+	errChan = make(chan error)
+
+	go pollRfkill(context.Background(), root, 10*time.Millisecond, eventChan, errChan)
+
+	for range 2 {
+		select {
+		case event = <-eventChan:
+		case err = <-errChan:
+			t.Fatal(err)
+		}
+
+		if event.Op() != RfkillOpAdd {
+			t.Errorf("expected add event, got %v", event)
+		}
+	}
+
+	tErrorIf(t, os.WriteFile(filepath.Join(root, "rfkill0", "soft"), []byte("1\n"), 0o644))
+
+	select {
+	case event = <-eventChan:
+	case err = <-errChan:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for change event")
+	}
+
+	if event.String() != "idx 0 type wlan op change soft 1 hard 0" {
+		t.Errorf("unexpected change event %v", event)
+	}
+
+	tErrorIf(t, os.RemoveAll(filepath.Join(root, "rfkill1")))
+
+	select {
+	case event = <-eventChan:
+	case err = <-errChan:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for del event")
+	}
+
+	if event.Op() != RfkillOpDel || event.Index() != 1 {
+		t.Errorf("unexpected del event %v", event)
+	}
+}
+
+func TestPollRfkillCancel(t *testing.T) {
+	var (
+		root   string
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	root = rfkillTree(t)
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan struct{})
+
+	go func() {
+		pollRfkill(ctx, root, time.Hour, make(chan *RfkillEvent), make(chan error))
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected pollRfkill to return after cancel, got no return")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan struct{})
+
+	go func() {
+		readRfkillEvents(ctx, bytes.NewReader(make([]byte, RfkillEventSize)), make(chan *RfkillEvent), make(chan error))
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected readRfkillEvents to return after cancel, got no return")
+	}
+}
@@ -0,0 +1,276 @@
+package sstat
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// subscription is a subscriber of a [Scheduler].
+type subscription struct {
+	name       string
+	interval   time.Duration
+	next       time.Time
+	sampleChan chan Sample
+}
+
+// Scheduler reads the collectors of a [Registry] at the intervals of
+// their subscribers. Ticks are aligned to multiples of the interval
+// since the zero time, so a 2s and a 30s subscriber both wake up at
+// every full and half minute and a single wakeup serves every
+// subscriber due at the same time. A collector due for several
+// subscribers at once is read only once. Collectors are read
+// concurrently, so a slow collector delays only its own subscribers.
+type Scheduler struct {
+	registry      *Registry
+	mutex         sync.Mutex
+	subscriptions []*subscription
+	reading       map[string]bool
+	changed       chan struct{}
+}
+
+// NewScheduler returns a [Scheduler] of the collectors of registry,
+// such as [DefaultRegistry].
+func NewScheduler(registry *Registry) *Scheduler {
+	return &Scheduler{
+		registry: registry,
+		reading:  make(map[string]bool),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// nextTick reports the first multiple of interval since
+// the zero time after now.
+func nextTick(now time.Time, interval time.Duration) time.Time {
+	return now.Truncate(interval).Add(interval)
+}
+
+// sendLatest sends value to the buffered channel valueChan, replacing
+// the unreceived value if the channel is full. It must not be called
+// concurrently for the same channel.
+func sendLatest[T any](valueChan chan T, value T) {
+	for {
+		select {
+		case valueChan <- value:
+			return
+		default:
+		}
+
+		select {
+		case <-valueChan:
+		default:
+		}
+	}
+}
+
+func (scheduler *Scheduler) notify() {
+	select {
+	case scheduler.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe returns a channel receiving a [Sample] of the collector
+// name of the registry of scheduler as soon as [Scheduler.Run] runs and
+// then every interval, aligned to the wall clock. The channel holds
+// only the latest sample, so a slow subscriber misses samples rather
+// than delaying the scheduler. The returned function unsubscribes and
+// closes the channel. It returns an error if the registry has no
+// collector of that name or if interval is not positive.
+func (scheduler *Scheduler) Subscribe(name string, interval time.Duration) (<-chan Sample, func(), error) {
+	var (
+		sub *subscription
+		ok  bool
+	)
+
+	if interval <= 0 {
+		return nil, nil, fmt.Errorf("%s: non-positive interval %v", name, interval)
+	}
+
+	_, ok = scheduler.registry.Lookup(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: no such collector", name)
+	}
+
+	sub = &subscription{
+		name:       name,
+		interval:   interval,
+		sampleChan: make(chan Sample, 1),
+	}
+
+	scheduler.mutex.Lock()
+	scheduler.subscriptions = append(scheduler.subscriptions, sub)
+	scheduler.mutex.Unlock()
+
+	scheduler.notify()
+
+	return sub.sampleChan, func() {
+		var idx int
+
+		scheduler.mutex.Lock()
+		defer scheduler.mutex.Unlock()
+
+		idx = slices.Index(scheduler.subscriptions, sub)
+		if idx == -1 {
+			return
+		}
+
+		scheduler.subscriptions = slices.Delete(scheduler.subscriptions, idx, idx+1)
+		close(sub.sampleChan)
+	}, nil
+}
+
+// earliest reports the earliest time a subscriber is due
+// and whether if there are subscribers or not.
+func (scheduler *Scheduler) earliest() (value time.Time, ok bool) {
+	var sub *subscription
+
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	for _, sub = range scheduler.subscriptions {
+		if !ok || sub.next.Before(value) {
+			value, ok = sub.next, true
+		}
+	}
+
+	return value, ok
+}
+
+// deliver sends sample to the subscribers subs that are still subscribed.
+func (scheduler *Scheduler) deliver(subs []*subscription, sample Sample) {
+	var sub *subscription
+
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	for _, sub = range subs {
+		if slices.Contains(scheduler.subscriptions, sub) {
+			sendLatest(sub.sampleChan, sample)
+		}
+	}
+}
+
+// tick reads every collector with a subscriber due at now once, each in
+// its own goroutine, and sends each sample to the due subscribers as
+// soon as its collector returns. A collector still being read from an
+// earlier tick is not read again, so its subscribers miss this tick.
+func (scheduler *Scheduler) tick(ctx context.Context, now time.Time) {
+	var (
+		due       map[string][]*subscription
+		sub       *subscription
+		name      string
+		collector Collector
+		ok        bool
+	)
+
+	due = make(map[string][]*subscription)
+
+	scheduler.mutex.Lock()
+
+	for _, sub = range scheduler.subscriptions {
+		if sub.next.After(now) {
+			continue
+		}
+
+		sub.next = nextTick(now, sub.interval)
+
+		if scheduler.reading[sub.name] {
+			continue
+		}
+
+		due[sub.name] = append(due[sub.name], sub)
+	}
+
+	for name = range due {
+		scheduler.reading[name] = true
+	}
+
+	scheduler.mutex.Unlock()
+
+	for name = range due {
+		collector, ok = scheduler.registry.Lookup(name)
+		if !ok {
+			scheduler.mutex.Lock()
+			delete(scheduler.reading, name)
+			scheduler.mutex.Unlock()
+
+			scheduler.deliver(due[name], Sample{
+				Name: name,
+				Time: now,
+				Err:  fmt.Errorf("%s: no such collector", name),
+			})
+
+			continue
+		}
+
+		go func(name string, collector Collector, subs []*subscription) {
+			var sample Sample
+
+			sample = CollectSample(ctx, collector)
+
+			scheduler.mutex.Lock()
+			delete(scheduler.reading, name)
+			scheduler.mutex.Unlock()
+
+			scheduler.deliver(subs, sample)
+		}(name, collector, due[name])
+	}
+}
+
+// Run reads the collectors for the subscribers of scheduler until ctx
+// is done, closing the channels of every subscriber on return.
+// Subscribers may be added and removed while Run runs. Run does not
+// wait for collectors still being read when ctx is done; their samples
+// are dropped, and collectors should return once ctx is done rather
+// than outlive Run.
+func (scheduler *Scheduler) Run(ctx context.Context) error {
+	var (
+		timer *time.Timer
+		wake  <-chan time.Time
+		next  time.Time
+		ok    bool
+		sub   *subscription
+	)
+
+	defer func() {
+		scheduler.mutex.Lock()
+		defer scheduler.mutex.Unlock()
+
+		for _, sub = range scheduler.subscriptions {
+			close(sub.sampleChan)
+		}
+
+		scheduler.subscriptions = nil
+	}()
+
+	for {
+		next, ok = scheduler.earliest()
+
+		wake = nil
+		if ok {
+			timer = time.NewTimer(time.Until(next))
+			wake = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+
+			return ctx.Err()
+		case <-scheduler.changed:
+			if timer != nil {
+				timer.Stop()
+			}
+
+			continue
+		case <-wake:
+		}
+
+		scheduler.tick(ctx, time.Now())
+	}
+}
@@ -0,0 +1,53 @@
+package sstat_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Poll the memory every 2 seconds and the batteries every 30 seconds.
+func ExampleScheduler() {
+	var (
+		scheduler          *sstat.Scheduler
+		memChan, batChan   <-chan sstat.Sample
+		sample             sstat.Sample
+		memTotal, memAvail int
+		err                error
+	)
+
+	scheduler = sstat.NewScheduler(sstat.DefaultRegistry)
+
+	memChan, _, err = scheduler.Subscribe("memory", 2*time.Second)
+	if err != nil {
+		panic(err)
+	}
+
+	batChan, _, err = scheduler.Subscribe("batteries", 30*time.Second)
+	if err != nil {
+		panic(err)
+	}
+
+	go scheduler.Run(context.Background())
+
+	for {
+		select {
+		case sample = <-memChan:
+			if sample.Err != nil {
+				panic(sample.Err)
+			}
+
+			memTotal, _ = sample.Value.(*sstat.MemInfo).MemTotal()
+			memAvail, _ = sample.Value.(*sstat.MemInfo).MemAvailable()
+			fmt.Printf("memory: %d kB available of %d kB\n", memAvail, memTotal)
+		case sample = <-batChan:
+			if sample.Err != nil {
+				panic(sample.Err)
+			}
+
+			fmt.Printf("batteries: %d\n", len(sample.Value.([]*sstat.BatteryInfo)))
+		}
+	}
+}
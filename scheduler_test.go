@@ -0,0 +1,211 @@
+package sstat
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNextTick(t *testing.T) {
+	var (
+		base  time.Time
+		tests []struct {
+			now      time.Time
+			interval time.Duration
+			want     time.Time
+		}
+		idx int
+	)
+
+	base = time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	tests = []struct {
+		now      time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{base, 2 * time.Second, base.Add(2 * time.Second)},
+		{base.Add(time.Millisecond), 2 * time.Second, base.Add(2 * time.Second)},
+		{base.Add(1999 * time.Millisecond), 2 * time.Second, base.Add(2 * time.Second)},
+		{base.Add(31 * time.Second), 30 * time.Second, base.Add(time.Minute)},
+		{base.Add(29 * time.Second), 30 * time.Second, base.Add(30 * time.Second)},
+		{base.Add(59 * time.Second), time.Minute, base.Add(time.Minute)},
+	}
+
+	for idx = range tests {
+		if !nextTick(tests[idx].now, tests[idx].interval).Equal(tests[idx].want) {
+			t.Errorf("nextTick(%v, %v) = %v, want %v", tests[idx].now, tests[idx].interval, nextTick(tests[idx].now, tests[idx].interval), tests[idx].want)
+		}
+	}
+}
+
+func TestSendLatest(t *testing.T) {
+	var valueChan chan int
+
+	valueChan = make(chan int, 1)
+
+	sendLatest(valueChan, 1)
+	sendLatest(valueChan, 2)
+	sendLatest(valueChan, 3)
+
+	if <-valueChan != 3 || len(valueChan) != 0 {
+		t.Error("sendLatest did not keep only the latest value")
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	var (
+		reads      atomic.Int64
+		registry   *Registry
+		scheduler  *Scheduler
+		fast, slow <-chan Sample
+		unsubFast  func()
+		sample     Sample
+		ctx        context.Context
+		cancel     context.CancelFunc
+		done       chan error
+		ok         bool
+		err        error
+	)
+
+	registry = NewRegistry(NewCollector("count", func(context.Context) (int64, error) {
+		return reads.Add(1), nil
+	}))
+
+	scheduler = NewScheduler(registry)
+
+	_, _, err = scheduler.Subscribe("missing", time.Second)
+	if err == nil {
+		t.Error("Subscribe accepted an unknown collector")
+	}
+
+	_, _, err = scheduler.Subscribe("count", 0)
+	if err == nil {
+		t.Error("Subscribe accepted a zero interval")
+	}
+
+	fast, unsubFast, err = scheduler.Subscribe("count", 20*time.Millisecond)
+	tErrorIf(t, err)
+
+	slow, _, err = scheduler.Subscribe("count", time.Hour)
+	tErrorIf(t, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan error)
+
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+
+	sample = <-slow
+	if sample.Name != "count" || sample.Value != int64(1) {
+		t.Errorf("first slow sample = %+v, want a single read", sample)
+	}
+
+	sample = <-fast
+	if sample.Value != int64(1) {
+		t.Errorf("first fast sample = %+v, want the read of the slow sample", sample)
+	}
+
+	sample = <-fast
+	if sample.Value.(int64) < 2 {
+		t.Errorf("second fast sample = %+v, want a new read", sample)
+	}
+
+	unsubFast()
+	unsubFast()
+
+	for range fast {
+	}
+
+	cancel()
+
+	err = <-done
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v, want context.Canceled", err)
+	}
+
+	_, ok = <-slow
+	if ok {
+		t.Error("Run did not close the channel of the slow subscriber")
+	}
+}
+
+func TestSchedulerSlowCollector(t *testing.T) {
+	var (
+		release    chan struct{}
+		registry   *Registry
+		scheduler  *Scheduler
+		fast, slow <-chan Sample
+		sample     Sample
+		ctx        context.Context
+		cancel     context.CancelFunc
+		done       chan error
+		err        error
+	)
+
+	release = make(chan struct{})
+
+	registry = NewRegistry(
+		NewCollector("fast", func(context.Context) (int, error) {
+			return 1, nil
+		}),
+		NewCollector("slow", func(ctx context.Context) (int, error) {
+			select {
+			case <-release:
+				return 2, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}),
+	)
+
+	scheduler = NewScheduler(registry)
+
+	fast, _, err = scheduler.Subscribe("fast", 10*time.Millisecond)
+	tErrorIf(t, err)
+
+	slow, _, err = scheduler.Subscribe("slow", 10*time.Millisecond)
+	tErrorIf(t, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan error)
+
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+
+	for range 3 {
+		sample = <-fast
+		if sample.Value != 1 {
+			t.Errorf("expected fast sample 1, got %+v", sample)
+		}
+	}
+
+	release <- struct{}{}
+
+	sample = <-slow
+	if sample.Value != 2 {
+		t.Errorf("expected slow sample 2, got %+v", sample)
+	}
+
+	cancel()
+
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after cancel, got no return")
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	for range fast {
+	}
+
+	for range slow {
+	}
+}
@@ -0,0 +1,386 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ProcPath is the directory where the process information
+// pseudo-filesystem is mounted.
+const ProcPath string = "/proc"
+
+// SmapsInfo reports the memory consumption of a process or of a single
+// mapping of a process. Values are represented in kilobytes.
+// Documentation for methods are taken from [proc_pid_smaps(5)].
+//
+// [proc_pid_smaps(5)]: https://man.archlinux.org/man/proc_pid_smaps.5.en
+type SmapsInfo struct {
+	info map[string]int
+}
+
+// Populate sets the values of every integer
+// pointer associated with a key.
+func (info *SmapsInfo) Populate(vars map[string]*int) error {
+	var (
+		key     string
+		value   *int
+		missing []string
+		ok      bool
+	)
+
+	for key, value = range vars {
+		*value, ok = info.Key(key)
+		if !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("%s: missing SmapsInfo key(s)", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Key reports the value of the specified smaps key
+// and whether if the key is valid or not.
+func (info *SmapsInfo) Key(key string) (value int, ok bool) {
+	value, ok = info.info[key]
+
+	return value, ok
+}
+
+// Rss reports the amount of the mapping that is
+// currently resident in RAM.
+func (info *SmapsInfo) Rss() (value int, ok bool) {
+	return info.Key("Rss")
+}
+
+// Pss reports the proportional share of the mapping that is
+// resident in RAM. Pages shared by N processes count as 1/N.
+func (info *SmapsInfo) Pss() (value int, ok bool) {
+	return info.Key("Pss")
+}
+
+// PssAnon (since Linux 5.0) reports the proportional share
+// of anonymous pages.
+func (info *SmapsInfo) PssAnon() (value int, ok bool) {
+	return info.Key("Pss_Anon")
+}
+
+// PssFile (since Linux 5.0) reports the proportional share
+// of file-backed pages.
+func (info *SmapsInfo) PssFile() (value int, ok bool) {
+	return info.Key("Pss_File")
+}
+
+// PssShmem (since Linux 5.0) reports the proportional share
+// of shared memory pages.
+func (info *SmapsInfo) PssShmem() (value int, ok bool) {
+	return info.Key("Pss_Shmem")
+}
+
+// Swap reports the amount of would-be-anonymous memory
+// that is swapped out.
+func (info *SmapsInfo) Swap() (value int, ok bool) {
+	return info.Key("Swap")
+}
+
+// SwapPss (since Linux 4.3) reports the proportional share
+// of swapped out pages.
+func (info *SmapsInfo) SwapPss() (value int, ok bool) {
+	return info.Key("SwapPss")
+}
+
+// SmapsMapping reports the memory consumption of a
+// single mapping of a process.
+type SmapsMapping struct {
+	SmapsInfo
+	start, end uint64
+	offset     uint64
+	perms      string
+	dev        string
+	inode      int
+	pathname   string
+	vmFlags    []string
+}
+
+// Start reports the start address of the mapping.
+func (mapping *SmapsMapping) Start() (value uint64) {
+	return mapping.start
+}
+
+// End reports the end address of the mapping.
+func (mapping *SmapsMapping) End() (value uint64) {
+	return mapping.end
+}
+
+// Offset reports the offset into the mapped file.
+func (mapping *SmapsMapping) Offset() (value uint64) {
+	return mapping.offset
+}
+
+// Perms reports the permissions of the mapping, e.g. "r-xp".
+func (mapping *SmapsMapping) Perms() (value string) {
+	return mapping.perms
+}
+
+// Dev reports the device of the mapped file as "major:minor".
+func (mapping *SmapsMapping) Dev() (value string) {
+	return mapping.dev
+}
+
+// Inode reports the inode of the mapped file.
+func (mapping *SmapsMapping) Inode() (value int) {
+	return mapping.inode
+}
+
+// Pathname reports the file backing the mapping or a pseudo-path
+// such as "[heap]". The value is empty for anonymous mappings.
+func (mapping *SmapsMapping) Pathname() (value string) {
+	return mapping.pathname
+}
+
+// VmFlags reports the kernel flags associated with the mapping.
+func (mapping *SmapsMapping) VmFlags() (value []string) {
+	return mapping.vmFlags
+}
+
+func parseSmapsHeader(text string) (*SmapsMapping, error) {
+	var (
+		mapping    *SmapsMapping
+		fields     []string
+		start, end string
+		ok         bool
+		err        error
+	)
+
+	fields = strings.Fields(text)
+	if len(fields) < 5 {
+		return nil, errors.New("invalid smaps header format")
+	}
+
+	start, end, ok = strings.Cut(fields[0], "-")
+	if !ok {
+		return nil, errors.New("invalid smaps address format")
+	}
+
+	mapping = &SmapsMapping{
+		SmapsInfo: SmapsInfo{
+			info: make(map[string]int),
+		},
+		perms: fields[1],
+		dev:   fields[3],
+	}
+
+	mapping.start, err = strconv.ParseUint(start, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping.end, err = strconv.ParseUint(end, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping.offset, err = strconv.ParseUint(fields[2], 16, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping.inode, err = strconv.Atoi(fields[4])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) > 5 {
+		mapping.pathname = strings.Join(fields[5:], " ")
+	}
+
+	return mapping, nil
+}
+
+func parseSmapsLine(mapping *SmapsMapping, text string) error {
+	var (
+		fields []string
+		key    string
+		value  int
+		err    error
+	)
+
+	fields = strings.Fields(text)
+	if len(fields) == 0 || !strings.HasSuffix(fields[0], ":") {
+		return errors.New("invalid smaps format")
+	}
+
+	key = fields[0][:len(fields[0])-1]
+	if key == "VmFlags" {
+		mapping.vmFlags = fields[1:]
+
+		return nil
+	}
+
+	if len(fields) != 2 && len(fields) != 3 {
+		return errors.New("invalid smaps format")
+	}
+
+	value, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return err
+	}
+
+	mapping.info[key] = value
+
+	return nil
+}
+
+func smaps(path string) ([]*SmapsMapping, error) {
+	var (
+		mappings []*SmapsMapping
+		err      error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var (
+			mapping *SmapsMapping
+			fields  []string
+			err     error
+		)
+
+		fields = strings.Fields(text)
+		if len(fields) != 0 && !strings.HasSuffix(fields[0], ":") {
+			mapping, err = parseSmapsHeader(text)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", path, err)
+			}
+
+			mappings = append(mappings, mapping)
+
+			return true, nil
+		}
+
+		if len(mappings) == 0 {
+			return false, fmt.Errorf("%s: invalid smaps format", path)
+		}
+
+		err = parseSmapsLine(mappings[len(mappings)-1], text)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+func smapsRollup(path string) (*SmapsInfo, error) {
+	var (
+		mappings []*SmapsMapping
+		err      error
+	)
+
+	mappings, err = smaps(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mappings) == 0 {
+		return &SmapsInfo{info: make(map[string]int)}, nil
+	}
+
+	return &mappings[0].SmapsInfo, nil
+}
+
+// SmapsRollup returns the memory consumption of the process pid summed
+// over every mapping, as found in [ProcPath]/<pid>/smaps_rollup.
+// Kernel threads report no keys.
+func SmapsRollup(pid int) (*SmapsInfo, error) {
+	return smapsRollup(filepath.Join(ProcPath, strconv.Itoa(pid), "smaps_rollup"))
+}
+
+// Smaps returns the memory consumption of every mapping of the
+// process pid, as found in [ProcPath]/<pid>/smaps.
+func Smaps(pid int) ([]*SmapsMapping, error) {
+	return smaps(filepath.Join(ProcPath, strconv.Itoa(pid), "smaps"))
+}
+
+func procExecutable(procDir string) (string, error) {
+	var (
+		exe string
+		err error
+	)
+
+	exe, err = os.Readlink(filepath.Join(procDir, "exe"))
+	if err == nil {
+		return filepath.Base(strings.TrimSuffix(exe, " (deleted)")), nil
+	}
+
+	return PathReadStr(filepath.Join(procDir, "comm"))
+}
+
+func pssByExecutable(dir string) (map[string]int, error) {
+	var (
+		pssMap       map[string]int
+		procDirs     []string
+		procDir, exe string
+		smapsInfo    *SmapsInfo
+		pss          int
+		ok           bool
+		err          error
+	)
+
+	procDirs, err = filepath.Glob(filepath.Join(dir, "[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	pssMap = make(map[string]int)
+
+	for _, procDir = range procDirs {
+		smapsInfo, err = smapsRollup(filepath.Join(procDir, "smaps_rollup"))
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.ESRCH) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		pss, ok = smapsInfo.Pss()
+		if !ok {
+			continue
+		}
+
+		exe, err = procExecutable(procDir)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		pssMap[exe] += pss
+	}
+
+	return pssMap, nil
+}
+
+// PssByExecutable returns the [SmapsInfo.Pss] of every readable process
+// in [ProcPath] summed per executable name. The name of the executable is
+// taken from the exe symlink, falling back to comm when it cannot be read.
+// Processes that exit or deny access while being read are skipped.
+func PssByExecutable() (map[string]int, error) {
+	return pssByExecutable(ProcPath)
+}
@@ -0,0 +1,64 @@
+package sstat_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the proportional memory usage of the current process.
+func ExampleSmapsRollup() {
+	var (
+		smapsInfo *sstat.SmapsInfo
+		pss       int
+		err       error
+	)
+
+	smapsInfo, err = sstat.SmapsRollup(os.Getpid())
+	if err != nil {
+		panic(err)
+	}
+
+	pss, _ = smapsInfo.Pss()
+	fmt.Printf("PSS: %d kB\n", pss)
+}
+
+// Print the mappings of the current process.
+func ExampleSmaps() {
+	var (
+		mappings []*sstat.SmapsMapping
+		rss      int
+		idx      int
+		err      error
+	)
+
+	mappings, err = sstat.Smaps(os.Getpid())
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range mappings {
+		rss, _ = mappings[idx].Rss()
+		fmt.Printf("%x %s: %d kB\n", mappings[idx].Start(), mappings[idx].Pathname(), rss)
+	}
+}
+
+// Print how much memory each executable truly costs.
+func ExamplePssByExecutable() {
+	var (
+		pssMap map[string]int
+		exe    string
+		pss    int
+		err    error
+	)
+
+	pssMap, err = sstat.PssByExecutable()
+	if err != nil {
+		panic(err)
+	}
+
+	for exe, pss = range pssMap {
+		fmt.Printf("%s: %d kB\n", exe, pss)
+	}
+}
@@ -0,0 +1,111 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSmaps string = `56219634c000-56219634e000 r--p 00000000 fe:00 681885                     /usr/bin/head
+Size:                  8 kB
+Rss:                   8 kB
+Pss:                   4 kB
+Swap:                  0 kB
+THPeligible:           0
+VmFlags: rd mr mw me
+7ffd91d14000-7ffd91d35000 rw-p 00000000 00:00 0                          [stack]
+Size:                132 kB
+Rss:                  12 kB
+Pss:                  12 kB
+Swap:                  0 kB
+VmFlags: rd wr mr mw me gd ac
+`
+
+const testSmapsRollup string = `557ff8efc000-7ffd91d35000 ---p 00000000 00:00 0                          [rollup]
+Rss:                1404 kB
+Pss:                 488 kB
+Pss_Anon:            104 kB
+Pss_File:            384 kB
+Pss_Shmem:             0 kB
+Swap:                  0 kB
+SwapPss:               0 kB
+`
+
+func TestSmaps(t *testing.T) {
+	var (
+		root     string
+		mappings []*SmapsMapping
+		pss      int
+		err      error
+	)
+
+	root = tmpTree(t, map[string]string{"smaps": testSmaps})
+
+	mappings, err = smaps(filepath.Join(root, "smaps"))
+	tErrorIf(t, err)
+
+	if len(mappings) != 2 {
+		t.Fatalf("expected %d mappings, got %d", 2, len(mappings))
+	}
+
+	if mappings[0].Pathname() != "/usr/bin/head" || mappings[0].Start() != 0x56219634c000 || len(mappings[1].VmFlags()) != 7 {
+		t.Errorf("unexpected mappings %+v %+v", *mappings[0], *mappings[1])
+	}
+
+	pss, _ = mappings[1].Pss()
+	if pss != 12 {
+		t.Errorf("expected %d, got %d", 12, pss)
+	}
+
+	_, err = Smaps(os.Getpid())
+	tErrorIf(t, err)
+}
+
+func TestSmapsRollup(t *testing.T) {
+	var (
+		root      string
+		smapsInfo *SmapsInfo
+		pssAnon   int
+		err       error
+	)
+
+	root = tmpTree(t, map[string]string{"smaps_rollup": testSmapsRollup})
+
+	smapsInfo, err = smapsRollup(filepath.Join(root, "smaps_rollup"))
+	tErrorIf(t, err)
+
+	tErrorIf(t, smapsInfo.Populate(map[string]*int{"Pss_Anon": &pssAnon}))
+
+	if pssAnon != 104 {
+		t.Errorf("expected %d, got %d", 104, pssAnon)
+	}
+
+	_, err = SmapsRollup(os.Getpid())
+	tErrorIf(t, err)
+}
+
+func TestPssByExecutable(t *testing.T) {
+	var (
+		root   string
+		pssMap map[string]int
+		err    error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"100/smaps_rollup": testSmapsRollup,
+		"100/comm":         "electron\n",
+		"200/smaps_rollup": testSmapsRollup,
+		"200/comm":         "electron\n",
+		"300/comm":         "kthreadd\n",
+	})
+
+	pssMap, err = pssByExecutable(root)
+	tErrorIf(t, err)
+
+	if len(pssMap) != 1 || pssMap["electron"] != 976 {
+		t.Errorf("unexpected PSS per executable %v", pssMap)
+	}
+
+	_, err = PssByExecutable()
+	tErrorIf(t, err)
+}
@@ -0,0 +1,147 @@
+package sstat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// SnapshotVersion is the version of the JSON schema of [Snapshot].
+// It is incremented whenever a field is renamed, removed or changes
+// meaning, so that consumers can reject snapshots they cannot read.
+const SnapshotVersion int = 1
+
+// Snapshot reports the state of the whole system at a single point in
+// time. Readers whose files do not exist or require root on the system,
+// such as batteries on a desktop, are left empty. Readers failing with
+// any other error are left empty and reported in Errors. Snapshots
+// marshal to JSON with a stable schema, see [SnapshotVersion].
+type Snapshot struct {
+	Version             int                      `json:"version"`
+	Time                time.Time                `json:"time"`
+	Host                *HostInfo                `json:"host,omitempty"`
+	Virt                *VirtInfo                `json:"virt,omitempty"`
+	User                *UserInfo                `json:"user,omitempty"`
+	Memory              *MemInfo                 `json:"memory,omitempty"`
+	HugePages           []*HugePageInfo          `json:"huge_pages,omitempty"`
+	NodeHugePages       []*HugePageInfo          `json:"node_huge_pages,omitempty"`
+	TransparentHugePage *TransparentHugePageInfo `json:"transparent_huge_page,omitempty"`
+	PowerSupplies       []*PowerSupplyInfo       `json:"power_supplies,omitempty"`
+	Backlights          []*BacklightInfo         `json:"backlights,omitempty"`
+	LEDs                []*LEDInfo               `json:"leds,omitempty"`
+	Rfkills             []*RfkillInfo            `json:"rfkills,omitempty"`
+	DRMConnectors       []*DRMConnectorInfo      `json:"drm_connectors,omitempty"`
+	SoundCards          []*SoundCardInfo         `json:"sound_cards,omitempty"`
+	PCMSubstreams       []*PCMSubstreamInfo      `json:"pcm_substreams,omitempty"`
+	InputDevices        []*InputDeviceInfo       `json:"input_devices,omitempty"`
+	PCIDevices          []*PCIDeviceInfo         `json:"pci_devices,omitempty"`
+	USBDevices          []*USBDeviceInfo         `json:"usb_devices,omitempty"`
+	Modules             []*ModuleInfo            `json:"modules,omitempty"`
+	KernelTaint         uint64                   `json:"kernel_taint"`
+	Sessions            []*UtmpEntry             `json:"sessions,omitempty"`
+
+	// Errors reports the error of every reader that failed,
+	// by the name of its field in JSON, e.g. "power_supplies".
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// snapshotErr drops errors of readers whose files
+// do not exist or require root on the system.
+func snapshotErr(err error) error {
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return nil
+	}
+
+	return err
+}
+
+// snapshotReader reads the field name of a [Snapshot].
+type snapshotReader struct {
+	name string
+	read func() error
+}
+
+// readSnapshot runs readers, recording their errors in snapshot.
+func readSnapshot(snapshot *Snapshot, readers []snapshotReader) {
+	var (
+		reader snapshotReader
+		err    error
+	)
+
+	for _, reader = range readers {
+		err = snapshotErr(reader.read())
+		if err == nil {
+			continue
+		}
+
+		if snapshot.Errors == nil {
+			snapshot.Errors = make(map[string]string)
+		}
+
+		snapshot.Errors[reader.name] = err.Error()
+	}
+}
+
+// NewSnapshot returns a [Snapshot] of every reader of the system taken
+// now. Device names are not resolved from the ids databases. A failing
+// reader leaves its field empty and is reported in [Snapshot].Errors
+// rather than failing the whole snapshot.
+func NewSnapshot() *Snapshot {
+	var snapshot *Snapshot
+
+	snapshot = &Snapshot{
+		Version: SnapshotVersion,
+		Time:    time.Now(),
+	}
+
+	readSnapshot(snapshot, []snapshotReader{
+		{"host", func() (err error) { snapshot.Host, err = Host(); return err }},
+		{"virt", func() (err error) { snapshot.Virt, err = Virt(); return err }},
+		{"user", func() (err error) { snapshot.User, err = CurrentUser(); return err }},
+		{"memory", func() (err error) { snapshot.Memory, err = NewMemInfo(); return err }},
+		{"huge_pages", func() (err error) { snapshot.HugePages, err = HugePages(); return err }},
+		{"node_huge_pages", func() (err error) { snapshot.NodeHugePages, err = NodeHugePages("node*"); return err }},
+		{"transparent_huge_page", func() (err error) { snapshot.TransparentHugePage, err = TransparentHugePage(); return err }},
+		{"power_supplies", func() (err error) { snapshot.PowerSupplies, err = PowerSupplies("*"); return err }},
+		{"backlights", func() (err error) { snapshot.Backlights, err = Backlights("*"); return err }},
+		{"leds", func() (err error) { snapshot.LEDs, err = LEDs("*"); return err }},
+		{"rfkills", func() (err error) { snapshot.Rfkills, err = Rfkills("*"); return err }},
+		{"drm_connectors", func() (err error) { snapshot.DRMConnectors, err = DRMConnectors("*"); return err }},
+		{"sound_cards", func() (err error) { snapshot.SoundCards, err = SoundCards(); return err }},
+		{"pcm_substreams", func() (err error) { snapshot.PCMSubstreams, err = PCMSubstreams(); return err }},
+		{"input_devices", func() (err error) { snapshot.InputDevices, err = InputDevices(); return err }},
+		{"pci_devices", func() (err error) { snapshot.PCIDevices, err = PCIDevices("*", ""); return err }},
+		{"usb_devices", func() (err error) { snapshot.USBDevices, err = USBDevices("*", ""); return err }},
+		{"modules", func() (err error) { snapshot.Modules, err = Modules(); return err }},
+		{"kernel_taint", func() (err error) { snapshot.KernelTaint, err = KernelTaint(); return err }},
+		{"sessions", func() (err error) { snapshot.Sessions, err = Sessions(); return err }},
+	})
+
+	return snapshot
+}
+
+// UnmarshalJSON decodes snapshot from JSON, rejecting snapshots
+// of a newer [SnapshotVersion] than this package supports.
+func (snapshot *Snapshot) UnmarshalJSON(data []byte) error {
+	type plainSnapshot Snapshot
+
+	var (
+		obj plainSnapshot
+		err error
+	)
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	if obj.Version < 1 || obj.Version > SnapshotVersion {
+		return fmt.Errorf("%d: unsupported snapshot version", obj.Version)
+	}
+
+	*snapshot = Snapshot(obj)
+
+	return nil
+}
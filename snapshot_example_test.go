@@ -0,0 +1,47 @@
+package sstat_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print a snapshot of the system as JSON.
+func ExampleNewSnapshot() {
+	var (
+		snapshot *sstat.Snapshot
+		encoder  *json.Encoder
+		err      error
+	)
+
+	snapshot = sstat.NewSnapshot()
+
+	encoder = json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(snapshot)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Reconstruct a snapshot received from another machine.
+func ExampleSnapshot_UnmarshalJSON() {
+	var (
+		snapshot sstat.Snapshot
+		memTotal int
+		err      error
+	)
+
+	err = json.Unmarshal([]byte(`{"version":1,"time":"2024-01-02T03:04:05Z","memory":{"MemTotal":16309412}}`), &snapshot)
+	if err != nil {
+		panic(err)
+	}
+
+	memTotal, _ = snapshot.Memory.MemTotal()
+
+	fmt.Println(snapshot.Time.Year(), memTotal)
+	// Output: 2024 16309412
+}
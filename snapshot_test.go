@@ -0,0 +1,108 @@
+package sstat
+
+import (
+	"encoding/json"
+	"io/fs"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	var (
+		snapshot, decoded *Snapshot
+		data              []byte
+		err               error
+	)
+
+	if !checkPath(t, MemInfoPath) {
+		return
+	}
+
+	snapshot = NewSnapshot()
+
+	if snapshot.Version != SnapshotVersion || snapshot.Memory == nil {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	data, err = json.Marshal(snapshot)
+	tErrorIf(t, err)
+
+	decoded = &Snapshot{}
+
+	err = json.Unmarshal(data, decoded)
+	tErrorIf(t, err)
+
+	if !decoded.Time.Equal(snapshot.Time) {
+		t.Errorf("expected %v, got %v", snapshot.Time, decoded.Time)
+	}
+
+	decoded.Time = snapshot.Time
+
+	if !reflect.DeepEqual(decoded.Memory, snapshot.Memory) || !reflect.DeepEqual(decoded.PCIDevices, snapshot.PCIDevices) {
+		t.Errorf("expected the round trip of %s to be equal", data)
+	}
+}
+
+func TestReadSnapshot(t *testing.T) {
+	var snapshot *Snapshot
+
+	snapshot = &Snapshot{}
+
+	readSnapshot(snapshot, []snapshotReader{
+		{"memory", func() error {
+			snapshot.Memory = &MemInfo{info: map[string]int{"MemTotal": 4}}
+
+			return nil
+		}},
+		{"backlights", func() error { return fs.ErrNotExist }},
+		{"power_supplies", func() error { return &fs.PathError{Op: "read", Path: "BAT0/uevent", Err: syscall.EIO} }},
+		{"kernel_taint", func() error {
+			snapshot.KernelTaint = 1
+
+			return nil
+		}},
+	})
+
+	if snapshot.Memory == nil || snapshot.KernelTaint != 1 {
+		t.Errorf("expected the other readers to be kept, got %+v", snapshot)
+	}
+
+	if len(snapshot.Errors) != 1 || snapshot.Errors["power_supplies"] != "read BAT0/uevent: input/output error" {
+		t.Errorf("expected only the power_supplies error, got %v", snapshot.Errors)
+	}
+}
+
+func TestSnapshotUnmarshalJSON(t *testing.T) {
+	var (
+		snapshot Snapshot
+		data     string
+		valid    bool
+		err      error
+	)
+
+	for data, valid = range map[string]bool{
+		`{"version":1,"time":"2024-01-02T03:04:05Z","memory":{"MemTotal":4}}`: true,
+		`{"version":2}`:                           false,
+		`{"time":"2024-01-02T03:04:05Z"}`:         false,
+		`{"version":1,"memory":{"MemTotal":"4"}}`: false,
+	} {
+		snapshot = Snapshot{}
+
+		err = json.Unmarshal([]byte(data), &snapshot)
+		if !valid {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", data, snapshot)
+			}
+
+			continue
+		}
+
+		tErrorIf(t, err)
+
+		if !snapshot.Time.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) || snapshot.Memory.info["MemTotal"] != 4 {
+			t.Errorf("%s: unexpected snapshot %+v", data, snapshot)
+		}
+	}
+}
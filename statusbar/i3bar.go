@@ -0,0 +1,295 @@
+package statusbar
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// i3barHeader is the header of the [i3bar protocol].
+//
+// [i3bar protocol]: https://i3wm.org/docs/i3bar-protocol.html
+type i3barHeader struct {
+	Version     int  `json:"version"`
+	ClickEvents bool `json:"click_events"`
+}
+
+// i3barBlock is a [Block] as encoded by the i3bar protocol.
+type i3barBlock struct {
+	FullText  string `json:"full_text"`
+	ShortText string `json:"short_text,omitempty"`
+	Color     string `json:"color,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Urgent    bool   `json:"urgent,omitempty"`
+}
+
+// I3bar drives a status line of [Module] blocks
+// over the i3bar protocol, used by i3bar and swaybar.
+type I3bar struct {
+	modules []*Module
+	blocks  []i3barBlock
+	writer  io.Writer
+	reader  io.Reader
+	lines   int
+}
+
+// NewI3bar returns an [I3bar] writing the status line of modules to
+// writer, usually os.Stdout. Click events are read from reader, usually
+// os.Stdin. A nil reader disables click events.
+func NewI3bar(writer io.Writer, reader io.Reader, modules ...*Module) *I3bar {
+	return &I3bar{
+		modules: modules,
+		blocks:  make([]i3barBlock, len(modules)),
+		writer:  writer,
+		reader:  reader,
+	}
+}
+
+func (bar *I3bar) setBlock(idx int, block Block) {
+	bar.blocks[idx] = i3barBlock{
+		FullText:  block.FullText,
+		ShortText: block.ShortText,
+		Color:     block.Color,
+		Name:      bar.modules[idx].Name,
+		Instance:  bar.modules[idx].Instance,
+		Urgent:    block.Urgent,
+	}
+}
+
+func (bar *I3bar) update(idx int) {
+	var (
+		block Block
+		err   error
+	)
+
+	block, err = bar.modules[idx].Update()
+	if err != nil {
+		block = errorBlock(bar.modules[idx], err)
+	}
+
+	bar.setBlock(idx, block)
+}
+
+func (bar *I3bar) click(event ClickEvent) {
+	var (
+		module *Module
+		idx    int
+		err    error
+	)
+
+	for idx, module = range bar.modules {
+		if module.Name != event.Name || module.Instance != event.Instance {
+			continue
+		}
+
+		err = nil
+		if module.Click != nil {
+			err = module.Click(event)
+		}
+
+		if err != nil {
+			bar.setBlock(idx, errorBlock(module, err))
+
+			continue
+		}
+
+		bar.update(idx)
+	}
+}
+
+// writeLine writes the current blocks as an element
+// of the infinite array of status lines.
+func (bar *I3bar) writeLine() error {
+	var (
+		buf []byte
+		err error
+	)
+
+	buf, err = json.Marshal(bar.blocks)
+	if err != nil {
+		return err
+	}
+
+	if bar.lines != 0 {
+		buf = append([]byte{','}, buf...)
+	}
+
+	bar.lines++
+
+	_, err = bar.writer.Write(append(buf, '\n'))
+
+	return err
+}
+
+// schedule sends idx to updates at every trigger of module.
+func schedule(ctx context.Context, idx int, module *Module, updates chan<- int) {
+	var (
+		ticker  *time.Ticker
+		tick    <-chan time.Time
+		refresh <-chan struct{}
+		ok      bool
+	)
+
+	if module.Interval > 0 {
+		ticker = time.NewTicker(module.Interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	refresh = module.Refresh
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case _, ok = <-refresh:
+			if !ok {
+				refresh = nil
+
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case updates <- idx:
+		}
+	}
+}
+
+// readClicks decodes the infinite array of click events from reader.
+// i3bar writes the opening bracket and every event on its own line,
+// with the separating comma at the start of the line.
+func readClicks(ctx context.Context, reader io.Reader, clicks chan<- ClickEvent, errChan chan<- error) {
+	var (
+		scanner *bufio.Scanner
+		line    string
+		event   ClickEvent
+		err     error
+	)
+
+	scanner = bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line = strings.TrimLeft(strings.TrimSpace(scanner.Text()), "[,")
+		if line == "" {
+			continue
+		}
+
+		event = ClickEvent{}
+
+		err = json.Unmarshal([]byte(line), &event)
+		if err != nil {
+			errChan <- err
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case clicks <- event:
+		}
+	}
+
+	err = scanner.Err()
+	if err == nil {
+		err = io.EOF
+	}
+
+	errChan <- err
+}
+
+// stop stops every module of bar.
+func (bar *I3bar) stop() {
+	var idx int
+
+	for idx = range bar.modules {
+		if bar.modules[idx].Stop != nil {
+			bar.modules[idx].Stop()
+		}
+	}
+}
+
+// Run writes the header of the protocol followed by a status line
+// whenever a block changes until ctx is done. Clicks are dispatched to
+// the [Module] with the same name and instance as the click event.
+// The end of the click events stops the dispatching but not the bar.
+// Every module is stopped once Run returns, see [Module].
+func (bar *I3bar) Run(ctx context.Context) error {
+	var (
+		buf     []byte
+		updates chan int
+		clicks  chan ClickEvent
+		errChan chan error
+		event   ClickEvent
+		idx     int
+		err     error
+	)
+
+	defer bar.stop()
+
+	buf, err = json.Marshal(i3barHeader{
+		Version:     1,
+		ClickEvents: bar.reader != nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = bar.writer.Write(append(buf, "\n[\n"...))
+	if err != nil {
+		return err
+	}
+
+	for idx = range bar.modules {
+		bar.update(idx)
+	}
+
+	err = bar.writeLine()
+	if err != nil {
+		return err
+	}
+
+	updates = make(chan int)
+	clicks = make(chan ClickEvent)
+	errChan = make(chan error, 1)
+
+	for idx = range bar.modules {
+		go schedule(ctx, idx, bar.modules[idx], updates)
+	}
+
+	if bar.reader != nil {
+		go readClicks(ctx, bar.reader, clicks, errChan)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case idx = <-updates:
+			bar.update(idx)
+		case event = <-clicks:
+			bar.click(event)
+		case err = <-errChan:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+
+			continue
+		}
+
+		err = bar.writeLine()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package statusbar_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/andrieee44/sstat/statusbar"
+)
+
+// Run a swaybar status line with the memory usage, the first battery
+// and the laptop panel backlight. Use it as the status_command of the
+// bar block of the sway configuration.
+func ExampleI3bar() {
+	var (
+		backlight *statusbar.Module
+		err       error
+	)
+
+	backlight, err = statusbar.BacklightModule("intel_backlight")
+	if err != nil {
+		panic(err)
+	}
+
+	err = statusbar.NewI3bar(os.Stdout, os.Stdin,
+		statusbar.MemoryModule(2*time.Second),
+		statusbar.BatteryModule("BAT0", 30*time.Second),
+		backlight,
+	).Run(context.Background())
+	if err != nil {
+		panic(err)
+	}
+}
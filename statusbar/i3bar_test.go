@@ -0,0 +1,204 @@
+package statusbar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chanWriter sends every write to a channel so that
+// tests can wait for the lines of a running bar.
+type chanWriter chan string
+
+func (writer chanWriter) Write(buf []byte) (int, error) {
+	writer <- string(buf)
+
+	return len(buf), nil
+}
+
+func nextLine(t *testing.T, lines <-chan string) []i3barBlock {
+	var (
+		line   string
+		blocks []i3barBlock
+	)
+
+	select {
+	case line = <-lines:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for status line")
+	}
+
+	if !strings.HasPrefix(line, ",") {
+		t.Errorf("expected status line to continue the array, got %q", line)
+	}
+
+	if json.Unmarshal([]byte(strings.TrimPrefix(line, ",")), &blocks) != nil {
+		t.Fatalf("invalid status line %q", line)
+	}
+
+	return blocks
+}
+
+func TestI3bar(t *testing.T) {
+	var (
+		lines        chanWriter
+		clickReader  *io.PipeReader
+		clickWriter  *io.PipeWriter
+		refresh      chan struct{}
+		clicked      chan ClickEvent
+		counter      int
+		ctx          context.Context
+		cancel       context.CancelFunc
+		done         chan error
+		header, line string
+		blocks       []i3barBlock
+		event        ClickEvent
+		err          error
+	)
+
+	lines = make(chanWriter)
+	clickReader, clickWriter = io.Pipe()
+	refresh = make(chan struct{})
+	clicked = make(chan ClickEvent, 1)
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan error)
+
+	go func() {
+		done <- NewI3bar(lines, clickReader, &Module{
+			Name:     "counter",
+			Instance: "0",
+			Refresh:  refresh,
+			Update: func() (Block, error) {
+				counter++
+
+				return Block{FullText: strings.Repeat("+", counter), Urgent: counter > 2}, nil
+			},
+			Click: func(event ClickEvent) error {
+				clicked <- event
+
+				return nil
+			},
+		}, &Module{
+			Name: "broken",
+			Update: func() (Block, error) {
+				return Block{}, errors.New("no data")
+			},
+		}).Run(ctx)
+	}()
+
+	header = <-lines
+	if header != "{\"version\":1,\"click_events\":true}\n[\n" {
+		t.Errorf("unexpected header %q", header)
+	}
+
+	line = <-lines
+	if json.Unmarshal([]byte(line), &blocks) != nil || len(blocks) != 2 {
+		t.Fatalf("unexpected first status line %q", line)
+	}
+
+	if blocks[0].FullText != "+" || blocks[0].Name != "counter" || blocks[0].Instance != "0" {
+		t.Errorf("unexpected counter block %+v", blocks[0])
+	}
+
+	if blocks[1].FullText != "broken: no data" || !blocks[1].Urgent || blocks[1].Color != ColorCritical {
+		t.Errorf("unexpected error block %+v", blocks[1])
+	}
+
+	refresh <- struct{}{}
+
+	blocks = nextLine(t, lines)
+	if blocks[0].FullText != "++" || blocks[0].Urgent {
+		t.Errorf("unexpected refreshed block %+v", blocks[0])
+	}
+
+	go func() {
+		_, err := clickWriter.Write([]byte("[\n{\"name\":\"counter\",\"instance\":\"0\",\"button\":3,\"modifiers\":[\"Shift\"]}\n,{\"name\":\"unknown\",\"button\":1}\n"))
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	blocks = nextLine(t, lines)
+	if blocks[0].FullText != "+++" || !blocks[0].Urgent {
+		t.Errorf("unexpected clicked block %+v", blocks[0])
+	}
+
+	event = <-clicked
+	if event.Button != 3 || len(event.Modifiers) != 1 || event.Modifiers[0] != "Shift" {
+		t.Errorf("unexpected click event %+v", event)
+	}
+
+	blocks = nextLine(t, lines)
+	if blocks[0].FullText != "+++" {
+		t.Errorf("unexpected block after unknown click %+v", blocks[0])
+	}
+
+	tErrorIf(t, clickWriter.Close())
+
+	cancel()
+
+	err = <-done
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestI3barNoClicks(t *testing.T) {
+	var (
+		lines   chanWriter
+		ctx     context.Context
+		cancel  context.CancelFunc
+		done    chan error
+		header  string
+		stopped bool
+	)
+
+	lines = make(chanWriter)
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan error)
+
+	go func() {
+		done <- NewI3bar(lines, nil, &Module{
+			Name:     "ticker",
+			Interval: time.Millisecond,
+			Update: func() (Block, error) {
+				return Block{FullText: "tick"}, nil
+			},
+			Stop: func() {
+				stopped = true
+			},
+		}).Run(ctx)
+	}()
+
+	header = <-lines
+	if !strings.HasPrefix(header, "{\"version\":1,\"click_events\":false}") {
+		t.Errorf("unexpected header %q", header)
+	}
+
+	<-lines
+	nextLine(t, lines)
+
+	cancel()
+
+	for {
+		select {
+		case <-lines:
+		case <-done:
+			if !stopped {
+				t.Error("expected the module to be stopped")
+			}
+
+			return
+		}
+	}
+}
+
+func tErrorIf(t *testing.T, err error) {
+	if err != nil {
+		t.Error(err)
+	}
+}
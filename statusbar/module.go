@@ -0,0 +1,459 @@
+// Package statusbar composes status lines for desktop bars,
+// such as i3bar and swaybar, from the readers of package sstat.
+package statusbar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Block is a single segment of a status line.
+type Block struct {
+	// FullText is the text of the block.
+	FullText string
+
+	// ShortText is shown instead of FullText when the bar is too narrow.
+	ShortText string
+
+	// Color is the text color of the block in the #RRGGBB format.
+	// It is blank for the default color of the bar.
+	Color string
+
+	// Urgent reports whether the block needs the attention of the user.
+	Urgent bool
+
+	// Tooltip is shown when hovering over the block, if supported by the bar.
+	Tooltip string
+
+	// Class is the CSS class of the block, e.g. "critical",
+	// used by Waybar for styling.
+	Class string
+
+	// Percentage is the value of the block from 0 to 100,
+	// used by Waybar to choose format icons.
+	Percentage int
+}
+
+// Threshold styles a [Block] whose value lies in [Min, Max).
+type Threshold struct {
+	Min, Max float64
+	Class    string
+	Color    string
+	Urgent   bool
+}
+
+// ApplyThresholds sets the percentage of block to value and styles
+// block with the first of thresholds containing value, if any.
+func ApplyThresholds(block *Block, value float64, thresholds []Threshold) {
+	var idx int
+
+	block.Percentage = int(math.Round(value))
+
+	for idx = range thresholds {
+		if value >= thresholds[idx].Min && value < thresholds[idx].Max {
+			block.Class = thresholds[idx].Class
+			block.Color = thresholds[idx].Color
+			block.Urgent = thresholds[idx].Urgent
+
+			return
+		}
+	}
+}
+
+// ClickEvent reports a click of the user on a block.
+type ClickEvent struct {
+	// Name and Instance identify the clicked [Module].
+	Name     string `json:"name"`
+	Instance string `json:"instance"`
+
+	// Button is the X11 button number, e.g. 1 for left click,
+	// 3 for right click and 4 and 5 for scrolling up and down.
+	Button int `json:"button"`
+
+	// Modifiers are the held modifier keys, e.g. "Shift" or "Mod4".
+	Modifiers []string `json:"modifiers"`
+
+	// X and Y are the coordinates of the click relative to the output.
+	X int `json:"x"`
+	Y int `json:"y"`
+
+	// RelativeX and RelativeY are the coordinates of
+	// the click relative to the top left of the block.
+	RelativeX int `json:"relative_x"`
+	RelativeY int `json:"relative_y"`
+
+	// Width and Height are the size of the block in pixels.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Module produces the [Block] of a status line segment. Update is
+// called every Interval, whenever Refresh receives and after every
+// Click. A zero Interval or a nil Refresh disables the respective
+// trigger. Update and Click are never called concurrently. Stop is
+// called once the bar running the module returns.
+type Module struct {
+	// Name and Instance identify the module in click events.
+	Name     string
+	Instance string
+
+	Interval time.Duration
+	Refresh  <-chan struct{}
+
+	// Update reports the current block of the module. An error is
+	// shown in place of the block as an urgent block.
+	Update func() (Block, error)
+
+	// Click handles a click on the block of the module. It may be nil.
+	Click func(event ClickEvent) error
+
+	// Stop releases the resources of the module, such as watchers,
+	// once the module is no longer run. It may be nil.
+	Stop func()
+}
+
+// Colors of the blocks of the builtin modules.
+const (
+	ColorGood     string = "#00ff00"
+	ColorWarning  string = "#ffff00"
+	ColorCritical string = "#ff0000"
+)
+
+// MemoryThresholds are the thresholds of the percentage
+// of memory in use of [MemoryModule].
+var MemoryThresholds = []Threshold{
+	{Min: 90, Max: math.Inf(1), Class: "critical", Color: ColorCritical, Urgent: true},
+	{Min: 75, Max: 90, Class: "warning", Color: ColorWarning},
+}
+
+// BatteryThresholds are the thresholds of the capacity
+// of discharging batteries of [BatteryModule].
+var BatteryThresholds = []Threshold{
+	{Min: math.Inf(-1), Max: 10, Class: "critical", Color: ColorCritical, Urgent: true},
+	{Min: 10, Max: 25, Class: "warning", Color: ColorWarning},
+}
+
+// errorBlock reports err in place of the block of module.
+func errorBlock(module *Module, err error) Block {
+	return Block{
+		FullText: module.Name + ": " + err.Error(),
+		Color:    ColorCritical,
+		Urgent:   true,
+		Tooltip:  err.Error(),
+		Class:    "error",
+	}
+}
+
+// notify sends to the buffered channel refresh without blocking.
+// A full channel already has a pending refresh, so nothing is lost
+// if the module is no longer run.
+func notify(refresh chan<- struct{}) {
+	select {
+	case refresh <- struct{}{}:
+	default:
+	}
+}
+
+// MemoryModule reports the percentage of memory in use, computed
+// from MemAvailable of [sstat.MemInfo], every interval.
+// The block is styled with [MemoryThresholds].
+func MemoryModule(interval time.Duration) *Module {
+	return &Module{
+		Name:     "memory",
+		Interval: interval,
+		Update: func() (Block, error) {
+			var (
+				memInfo          *sstat.MemInfo
+				total, available int
+				err              error
+			)
+
+			memInfo, err = sstat.NewMemInfo()
+			if err != nil {
+				return Block{}, err
+			}
+
+			err = memInfo.Populate(map[string]*int{
+				"MemTotal":     &total,
+				"MemAvailable": &available,
+			})
+			if err != nil {
+				return Block{}, err
+			}
+
+			return memoryBlock(total, available)
+		},
+	}
+}
+
+// memoryBlock reports the block of [MemoryModule] from
+// the total and available memory in kB.
+func memoryBlock(total, available int) (Block, error) {
+	var (
+		used  float64
+		block Block
+	)
+
+	if total <= 0 {
+		return Block{}, errors.New("zero total memory")
+	}
+
+	used = float64(total-available) / float64(total) * 100
+
+	block = Block{
+		FullText:  fmt.Sprintf("MEM %.0f%%", used),
+		ShortText: fmt.Sprintf("%.0f%%", used),
+		Tooltip:   fmt.Sprintf("%.1f GiB of %.1f GiB available", float64(available)/(1<<20), float64(total)/(1<<20)),
+	}
+
+	ApplyThresholds(&block, used, MemoryThresholds)
+
+	return block, nil
+}
+
+// BatteryModule reports the capacity and status of the battery in
+// [sstat.PowerSupplyPath] + basepath, e.g. "BAT0", every interval.
+// Discharging batteries are styled with [BatteryThresholds],
+// so the block is urgent with the "critical" class below 10%.
+// Charging and full batteries have the "charging" class.
+func BatteryModule(basepath string, interval time.Duration) *Module {
+	return &Module{
+		Name:     "battery",
+		Instance: basepath,
+		Interval: interval,
+		Update: func() (Block, error) {
+			var (
+				batteryInfo      *sstat.BatteryInfo
+				capacity, status string
+				percent          int
+				block            Block
+				err              error
+			)
+
+			batteryInfo, err = sstat.Battery(basepath)
+			if err != nil {
+				return Block{}, err
+			}
+
+			err = batteryInfo.Populate(map[string]*string{
+				"POWER_SUPPLY_CAPACITY": &capacity,
+				"POWER_SUPPLY_STATUS":   &status,
+			})
+			if err != nil {
+				return Block{}, err
+			}
+
+			percent, err = strconv.Atoi(capacity)
+			if err != nil {
+				return Block{}, err
+			}
+
+			block = Block{
+				FullText:  fmt.Sprintf("%s %d%% %s", basepath, percent, status),
+				ShortText: fmt.Sprintf("%d%%", percent),
+				Tooltip:   basepath + ": " + status,
+			}
+
+			if status == "Charging" || status == "Full" {
+				block.Percentage = percent
+				block.Class = "charging"
+				block.Color = ColorGood
+
+				return block, nil
+			}
+
+			ApplyThresholds(&block, float64(percent), BatteryThresholds)
+
+			return block, nil
+		},
+	}
+}
+
+// BacklightModule reports the brightness of the backlight in
+// [sstat.BacklightPath] + basepath, e.g. "intel_backlight". It is
+// updated as soon as the brightness changes until it is stopped. The
+// backlight is watched from the first update of the module, so a module
+// that is never run holds no watcher. Scrolling up and down on the
+// block steps the brightness by 5%, which requires write access to the
+// backlight, see [sstat.BacklightController].
+func BacklightModule(basepath string) (*Module, error) {
+	var (
+		broadcaster *sstat.Broadcaster[*sstat.BacklightInfo]
+		unsubscribe func()
+		refresh     chan struct{}
+		watch       func()
+		once        sync.Once
+		mutex       sync.Mutex
+		watchErr    error
+		module      *Module
+		err         error
+	)
+
+	_, err = sstat.Backlight(basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh = make(chan struct{}, 1)
+
+	watch = func() {
+		var (
+			broadcasters  map[string]*sstat.Broadcaster[*sstat.BacklightInfo]
+			backlightChan <-chan *sstat.BacklightInfo
+			err           error
+		)
+
+		broadcasters, err = sstat.BacklightBroadcasters(basepath)
+		if err == nil && broadcasters[basepath] == nil {
+			err = fmt.Errorf("%s: no such backlight", basepath)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if err != nil {
+			watchErr = err
+
+			return
+		}
+
+		broadcaster = broadcasters[basepath]
+		backlightChan, unsubscribe = broadcaster.Subscribe(1)
+
+		go func() {
+			for range backlightChan {
+				notify(refresh)
+			}
+
+			mutex.Lock()
+			if watchErr == nil {
+				watchErr = broadcaster.Err()
+			}
+			mutex.Unlock()
+
+			notify(refresh)
+		}()
+	}
+
+	module = &Module{
+		Name:     "backlight",
+		Instance: basepath,
+		Refresh:  refresh,
+		Update: func() (Block, error) {
+			var (
+				backlightInfo *sstat.BacklightInfo
+				percent       float64
+				err           error
+			)
+
+			once.Do(watch)
+
+			mutex.Lock()
+			err = watchErr
+			mutex.Unlock()
+
+			if err != nil {
+				return Block{}, err
+			}
+
+			backlightInfo, err = sstat.Backlight(basepath)
+			if err != nil {
+				return Block{}, err
+			}
+
+			if backlightInfo.MaxBrightness() == 0 {
+				return Block{}, errors.New("zero max brightness")
+			}
+
+			percent = float64(backlightInfo.Brightness()) / float64(backlightInfo.MaxBrightness()) * 100
+
+			return Block{
+				FullText:   fmt.Sprintf("BRI %.0f%%", percent),
+				ShortText:  fmt.Sprintf("%.0f%%", percent),
+				Tooltip:    fmt.Sprintf("%s: %d/%d", basepath, backlightInfo.Brightness(), backlightInfo.MaxBrightness()),
+				Percentage: int(math.Round(percent)),
+			}, nil
+		},
+		Click: func(event ClickEvent) error {
+			var (
+				controller *sstat.BacklightController
+				err        error
+			)
+
+			if event.Button != 4 && event.Button != 5 {
+				return nil
+			}
+
+			controller, err = sstat.NewBacklightController(basepath)
+			if err != nil {
+				return err
+			}
+
+			if event.Button == 4 {
+				_, err = controller.StepPercent(5)
+			} else {
+				_, err = controller.StepPercent(-5)
+			}
+
+			return err
+		},
+		Stop: func() {
+			once.Do(func() {})
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if broadcaster != nil {
+				unsubscribe()
+				broadcaster.Stop()
+			}
+		},
+	}
+
+	return module, nil
+}
+
+// runModule calls emit with the block of module once and at every
+// trigger of module until ctx is done, stopping module on return.
+// Errors of module are emitted as error blocks while errors of emit
+// stop the module.
+func runModule(ctx context.Context, module *Module, emit func(block Block) error) error {
+	var (
+		updates chan int
+		block   Block
+		err     error
+	)
+
+	if module.Stop != nil {
+		defer module.Stop()
+	}
+
+	updates = make(chan int)
+
+	go schedule(ctx, 0, module, updates)
+
+	for {
+		block, err = module.Update()
+		if err != nil {
+			block = errorBlock(module, err)
+		}
+
+		err = emit(block)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updates:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package statusbar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryModule(t *testing.T) {
+	var (
+		block Block
+		err   error
+	)
+
+	block, err = MemoryModule(time.Second).Update()
+	tErrorIf(t, err)
+
+	if !strings.HasPrefix(block.FullText, "MEM ") || !strings.HasSuffix(block.ShortText, "%") {
+		t.Errorf("unexpected memory block %+v", block)
+	}
+}
+
+func TestMemoryBlock(t *testing.T) {
+	var (
+		block Block
+		err   error
+	)
+
+	block, err = memoryBlock(16309412, 4077353)
+	tErrorIf(t, err)
+
+	if block.FullText != "MEM 75%" || block.Class != "warning" {
+		t.Errorf("unexpected memory block %+v", block)
+	}
+
+	_, err = memoryBlock(0, 0)
+	if err == nil {
+		t.Error("expected error for zero total memory")
+	}
+}
+
+func TestBatteryModuleMissing(t *testing.T) {
+	var err error
+
+	_, err = BatteryModule("sstat-missing-battery", time.Second).Update()
+	if err == nil {
+		t.Error("expected error for missing battery")
+	}
+}
+
+func TestErrorBlock(t *testing.T) {
+	var block Block
+
+	block = errorBlock(&Module{Name: "disk"}, errors.New("no such device"))
+
+	if block.FullText != "disk: no such device" || !block.Urgent || block.Color != ColorCritical {
+		t.Errorf("unexpected error block %+v", block)
+	}
+}
+
+func TestApplyThresholds(t *testing.T) {
+	var (
+		block Block
+		value float64
+		want  Block
+	)
+
+	for value, want = range map[float64]Block{
+		-1:   {Percentage: -1, Class: "critical", Color: ColorCritical, Urgent: true},
+		9.6:  {Percentage: 10, Class: "critical", Color: ColorCritical, Urgent: true},
+		10:   {Percentage: 10, Class: "warning", Color: ColorWarning},
+		24.9: {Percentage: 25, Class: "warning", Color: ColorWarning},
+		25:   {Percentage: 25},
+		100:  {Percentage: 100},
+	} {
+		block = Block{}
+
+		ApplyThresholds(&block, value, BatteryThresholds)
+
+		if block != want {
+			t.Errorf("%v: expected %+v, got %+v", value, want, block)
+		}
+	}
+
+	block = Block{}
+
+	ApplyThresholds(&block, 95, MemoryThresholds)
+
+	if block.Class != "critical" || !block.Urgent {
+		t.Errorf("expected critical memory block, got %+v", block)
+	}
+}
@@ -0,0 +1,56 @@
+package statusbar
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// polybarEscaper escapes the text of blocks so that it
+// is not interpreted as a Polybar formatting tag.
+var polybarEscaper = strings.NewReplacer("%{", "%%{")
+
+// WritePolybar writes block as a single line with [Polybar] formatting
+// tags for a custom/script module with tail = true. Color sets the
+// foreground and Urgent underlines the block in [ColorCritical].
+//
+// [Polybar]: https://github.com/polybar/polybar/wiki/Formatting#format-tags
+func WritePolybar(writer io.Writer, block Block) error {
+	var (
+		builder strings.Builder
+		err     error
+	)
+
+	if block.Urgent {
+		builder.WriteString("%{u" + ColorCritical + "}%{+u}")
+	}
+
+	if block.Color != "" {
+		builder.WriteString("%{F" + block.Color + "}")
+	}
+
+	builder.WriteString(polybarEscaper.Replace(block.FullText))
+
+	if block.Color != "" {
+		builder.WriteString("%{F-}")
+	}
+
+	if block.Urgent {
+		builder.WriteString("%{-u}")
+	}
+
+	builder.WriteByte('\n')
+
+	_, err = io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// Polybar writes the block of module with [WritePolybar] whenever
+// it changes until ctx is done. Use it as the exec command of a
+// Polybar custom/script module with tail = true.
+func Polybar(ctx context.Context, writer io.Writer, module *Module) error {
+	return runModule(ctx, module, func(block Block) error {
+		return WritePolybar(writer, block)
+	})
+}
@@ -0,0 +1,29 @@
+package statusbar
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWritePolybar(t *testing.T) {
+	var (
+		buf   bytes.Buffer
+		block Block
+		want  string
+	)
+
+	for block, want = range map[Block]string{
+		{FullText: "MEM 42%"}:                                     "MEM 42%\n",
+		{FullText: "BAT0 20%", Color: ColorWarning}:               "%{F#ffff00}BAT0 20%%{F-}\n",
+		{FullText: "BAT0 5%", Color: ColorCritical, Urgent: true}: "%{u#ff0000}%{+u}%{F#ff0000}BAT0 5%%{F-}%{-u}\n",
+		{FullText: "literal %{F-}"}:                               "literal %%{F-}\n",
+	} {
+		buf.Reset()
+
+		tErrorIf(t, WritePolybar(&buf, block))
+
+		if buf.String() != want {
+			t.Errorf("expected %q, got %q", want, buf.String())
+		}
+	}
+}
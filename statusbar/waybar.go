@@ -0,0 +1,59 @@
+package statusbar
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// waybarBlock is a [Block] as expected by the return-type
+// json custom modules of [Waybar].
+//
+// [Waybar]: https://github.com/Alexays/Waybar/wiki/Module:-Custom
+type waybarBlock struct {
+	Text       string   `json:"text"`
+	Alt        string   `json:"alt,omitempty"`
+	Tooltip    string   `json:"tooltip,omitempty"`
+	Class      []string `json:"class,omitempty"`
+	Percentage int      `json:"percentage"`
+}
+
+// WriteWaybar writes block as a single line JSON object for a
+// Waybar custom module with "return-type": "json". ShortText
+// is written as "alt" and Urgent adds the "urgent" class.
+func WriteWaybar(writer io.Writer, block Block) error {
+	var (
+		waybar  waybarBlock
+		encoder *json.Encoder
+	)
+
+	waybar = waybarBlock{
+		Text:       block.FullText,
+		Alt:        block.ShortText,
+		Tooltip:    block.Tooltip,
+		Percentage: block.Percentage,
+	}
+
+	if block.Class != "" {
+		waybar.Class = append(waybar.Class, block.Class)
+	}
+
+	if block.Urgent {
+		waybar.Class = append(waybar.Class, "urgent")
+	}
+
+	encoder = json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(waybar)
+}
+
+// Waybar writes the block of module with [WriteWaybar] whenever it
+// changes until ctx is done. Use it as the exec command of a Waybar
+// custom module without an interval, since module schedules its
+// own updates.
+func Waybar(ctx context.Context, writer io.Writer, module *Module) error {
+	return runModule(ctx, module, func(block Block) error {
+		return WriteWaybar(writer, block)
+	})
+}
@@ -0,0 +1,29 @@
+package statusbar_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/andrieee44/sstat/statusbar"
+)
+
+// Feed a Waybar custom module with the first battery, using
+// a stricter critical threshold than the default.
+//
+//	"custom/battery": {
+//		"exec": "/path/to/this/program",
+//		"return-type": "json",
+//		"format": "{icon} {}",
+//		"format-icons": ["", "", "", "", ""]
+//	}
+func ExampleWaybar() {
+	var err error
+
+	statusbar.BatteryThresholds[0].Max = 15
+
+	err = statusbar.Waybar(context.Background(), os.Stdout, statusbar.BatteryModule("BAT0", 30*time.Second))
+	if err != nil {
+		panic(err)
+	}
+}
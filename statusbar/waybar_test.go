@@ -0,0 +1,88 @@
+package statusbar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWriteWaybar(t *testing.T) {
+	var (
+		buf   bytes.Buffer
+		block Block
+		want  string
+	)
+
+	for block, want = range map[Block]string{
+		{FullText: "MEM 42%", Percentage: 42}: `{"text":"MEM 42%","percentage":42}` + "\n",
+		{FullText: "BAT0 5% Discharging", ShortText: "5%", Tooltip: "BAT0: Discharging", Class: "critical", Urgent: true, Percentage: 5}: `{"text":"BAT0 5% Discharging","alt":"5%","tooltip":"BAT0: Discharging","class":["critical","urgent"],"percentage":5}` + "\n",
+		{FullText: "<b>", Class: "charging"}: `{"text":"<b>","class":["charging"],"percentage":0}` + "\n",
+	} {
+		buf.Reset()
+
+		tErrorIf(t, WriteWaybar(&buf, block))
+
+		if buf.String() != want {
+			t.Errorf("expected %s, got %s", want, buf.String())
+		}
+	}
+}
+
+func TestWaybar(t *testing.T) {
+	var (
+		lines   chanWriter
+		refresh chan struct{}
+		ctx     context.Context
+		cancel  context.CancelFunc
+		done    chan error
+		calls   int
+		stopped bool
+		line    string
+	)
+
+	lines = make(chanWriter)
+	refresh = make(chan struct{})
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan error)
+
+	go func() {
+		done <- Waybar(ctx, lines, &Module{
+			Name:    "flaky",
+			Refresh: refresh,
+			Update: func() (Block, error) {
+				calls++
+				if calls == 2 {
+					return Block{}, errors.New("read failed")
+				}
+
+				return Block{FullText: "ok"}, nil
+			},
+			Stop: func() {
+				stopped = true
+			},
+		})
+	}()
+
+	line = <-lines
+	if line != `{"text":"ok","percentage":0}`+"\n" {
+		t.Errorf("unexpected first line %q", line)
+	}
+
+	refresh <- struct{}{}
+
+	line = <-lines
+	if line != `{"text":"flaky: read failed","tooltip":"read failed","class":["error","urgent"],"percentage":0}`+"\n" {
+		t.Errorf("unexpected error line %q", line)
+	}
+
+	cancel()
+
+	if !errors.Is(<-done, context.Canceled) {
+		t.Error("expected context.Canceled")
+	}
+
+	if !stopped {
+		t.Error("expected the module to be stopped")
+	}
+}
@@ -0,0 +1,253 @@
+package sstat
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// USBPath is the directory where the information for
+// USB devices are located.
+const USBPath string = "/sys/bus/usb/devices"
+
+// USBDevPath is the directory where the USB
+// device nodes are located.
+const USBDevPath string = "/dev/bus/usb"
+
+// USBDeviceInfo reports USB device information. Documentation for the
+// object methods are taken from [sysfs-bus-usb].
+//
+// [sysfs-bus-usb]: https://www.kernel.org/doc/Documentation/ABI/stable/sysfs-bus-usb
+type USBDeviceInfo struct {
+	name                string
+	vendorId, productId int
+	manufacturer        string
+	product             string
+	serial              string
+	speed               float64
+	busnum, devnum      int
+	class               int
+	version             string
+	driver              string
+	vendorName          string
+	productName         string
+}
+
+// Name reports the name of the device in the bus-port.port format,
+// e.g. "1-1.2", or "usb1" for root hubs.
+func (info *USBDeviceInfo) Name() (value string) {
+	return info.name
+}
+
+// VendorId reports the vendor id of the device, e.g. 0x046d.
+func (info *USBDeviceInfo) VendorId() (value int) {
+	return info.vendorId
+}
+
+// ProductId reports the product id of the device.
+func (info *USBDeviceInfo) ProductId() (value int) {
+	return info.productId
+}
+
+// Manufacturer reports the manufacturer string descriptor of the device.
+// It is blank if the device has none.
+func (info *USBDeviceInfo) Manufacturer() (value string) {
+	return info.manufacturer
+}
+
+// Product reports the product string descriptor of the device.
+// It is blank if the device has none.
+func (info *USBDeviceInfo) Product() (value string) {
+	return info.product
+}
+
+// Serial reports the serial number string descriptor of the device.
+// It is blank if the device has none.
+func (info *USBDeviceInfo) Serial() (value string) {
+	return info.serial
+}
+
+// Speed reports the speed of the device in Mbit/s.
+//
+// Valid values are:
+//   - 0     : unknown speed
+//   - 1.5   : low speed
+//   - 12    : full speed
+//   - 480   : high speed
+//   - 5000  : SuperSpeed
+//   - 10000 : SuperSpeed+
+//   - 20000 : SuperSpeed+ Gen 2x2
+func (info *USBDeviceInfo) Speed() (value float64) {
+	return info.speed
+}
+
+// Busnum reports the number of the bus of the device.
+func (info *USBDeviceInfo) Busnum() (value int) {
+	return info.busnum
+}
+
+// Devnum reports the address of the device on its bus.
+func (info *USBDeviceInfo) Devnum() (value int) {
+	return info.devnum
+}
+
+// DevicePath reports the path of the device node of the
+// device in [USBDevPath], e.g. "/dev/bus/usb/001/004".
+func (info *USBDeviceInfo) DevicePath() (value string) {
+	return filepath.Join(USBDevPath, fmt.Sprintf("%03d", info.busnum), fmt.Sprintf("%03d", info.devnum))
+}
+
+// Class reports the device class of the device, e.g. 0x09 for hubs.
+// It is 0 if the class is defined by each interface.
+func (info *USBDeviceInfo) Class() (value int) {
+	return info.class
+}
+
+// Version reports the USB version supported by the device, e.g. "2.00".
+func (info *USBDeviceInfo) Version() (value string) {
+	return info.version
+}
+
+// Driver reports the driver bound to the device, e.g. "usb",
+// and whether if a driver is bound to the device or not.
+func (info *USBDeviceInfo) Driver() (value string, ok bool) {
+	return info.driver, info.driver != ""
+}
+
+// VendorName reports the name of the vendor from the ids database.
+// It is blank if the database was not supplied or lacks the vendor.
+func (info *USBDeviceInfo) VendorName() (value string) {
+	return info.vendorName
+}
+
+// ProductName reports the name of the product from the ids database.
+// It is blank if the database was not supplied or lacks the product.
+func (info *USBDeviceInfo) ProductName() (value string) {
+	return info.productName
+}
+
+func usbDevice(dir, basepath string) (*USBDeviceInfo, error) {
+	var (
+		usbInfo *USBDeviceInfo
+		key     string
+		intPtr  *int
+		strPtr  *string
+		readInt func(string) (int, error)
+		str     string
+		err     error
+	)
+
+	usbInfo = &USBDeviceInfo{
+		name: basepath,
+	}
+
+	for key, intPtr = range map[string]*int{
+		"idVendor":     &usbInfo.vendorId,
+		"idProduct":    &usbInfo.productId,
+		"bDeviceClass": &usbInfo.class,
+		"busnum":       &usbInfo.busnum,
+		"devnum":       &usbInfo.devnum,
+	} {
+		readInt = pathReadHex
+		if key == "busnum" || key == "devnum" {
+			readInt = PathReadInt
+		}
+
+		*intPtr, err = readInt(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for key, strPtr = range map[string]*string{
+		"manufacturer": &usbInfo.manufacturer,
+		"product":      &usbInfo.product,
+		"serial":       &usbInfo.serial,
+	} {
+		*strPtr, err = pathReadOptionalStr(filepath.Join(dir, basepath, key))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	str, err = PathReadStr(filepath.Join(dir, basepath, "speed"))
+	if err != nil {
+		return nil, err
+	}
+
+	// The kernel writes "unknown" for USB_SPEED_UNKNOWN.
+	usbInfo.speed, err = strconv.ParseFloat(str, 64)
+	if err != nil {
+		usbInfo.speed = 0
+	}
+
+	usbInfo.version, err = PathReadStr(filepath.Join(dir, basepath, "version"))
+	if err != nil {
+		return nil, err
+	}
+
+	usbInfo.version = strings.TrimSpace(usbInfo.version)
+
+	usbInfo.driver, err = pathDriver(filepath.Join(dir, basepath))
+	if err != nil {
+		return nil, err
+	}
+
+	return usbInfo, nil
+}
+
+func usbDevices(dir, glob string, db *IdsDatabase) ([]*USBDeviceInfo, error) {
+	var (
+		usbPaths []string
+		usbInfos []*USBDeviceInfo
+		usbInfo  *USBDeviceInfo
+		path     string
+		err      error
+	)
+
+	usbPaths, err = filepath.Glob(filepath.Join(dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path = range usbPaths {
+		if strings.Contains(filepath.Base(path), ":") {
+			continue
+		}
+
+		usbInfo, err = usbDevice(dir, filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+
+		if db != nil {
+			usbInfo.vendorName, _ = db.Vendor(usbInfo.vendorId)
+			usbInfo.productName, _ = db.Device(usbInfo.vendorId, usbInfo.productId)
+		}
+
+		usbInfos = append(usbInfos, usbInfo)
+	}
+
+	return usbInfos, nil
+}
+
+// USBDevices returns all USB device information in [USBPath] + glob.
+// Interfaces of the devices, such as "1-1:1.0", are skipped.
+// If idsPath is not blank, the usb.ids database in idsPath, such as
+// [USBIdsPath], is used to resolve the names of the devices.
+func USBDevices(glob, idsPath string) ([]*USBDeviceInfo, error) {
+	var (
+		db  *IdsDatabase
+		err error
+	)
+
+	if idsPath != "" {
+		db, err = LoadIds(idsPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return usbDevices(USBPath, glob, db)
+}
@@ -0,0 +1,25 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print every USB device like lsusb.
+func ExampleUSBDevices() {
+	var (
+		usbInfos []*sstat.USBDeviceInfo
+		idx      int
+		err      error
+	)
+
+	usbInfos, err = sstat.USBDevices("*", sstat.USBIdsPath)
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range usbInfos {
+		fmt.Printf("Bus %03d Device %03d: ID %04x:%04x %s %s\n", usbInfos[idx].Busnum(), usbInfos[idx].Devnum(), usbInfos[idx].VendorId(), usbInfos[idx].ProductId(), usbInfos[idx].VendorName(), usbInfos[idx].ProductName())
+	}
+}
@@ -0,0 +1,93 @@
+package sstat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func usbTree(t *testing.T) string {
+	var root string
+
+	root = tmpTree(t, map[string]string{
+		"devices/usb1/idVendor":       "1d6b\n",
+		"devices/usb1/idProduct":      "0002\n",
+		"devices/usb1/bDeviceClass":   "09\n",
+		"devices/usb1/busnum":         "1\n",
+		"devices/usb1/devnum":         "1\n",
+		"devices/usb1/manufacturer":   "Linux 6.6.8 xhci-hcd\n",
+		"devices/usb1/product":        "xHCI Host Controller\n",
+		"devices/usb1/serial":         "0000:00:14.0\n",
+		"devices/usb1/speed":          "480\n",
+		"devices/usb1/version":        " 2.00\n",
+		"devices/1-1/idVendor":        "046d\n",
+		"devices/1-1/idProduct":       "c077\n",
+		"devices/1-1/bDeviceClass":    "00\n",
+		"devices/1-1/busnum":          "1\n",
+		"devices/1-1/devnum":          "4\n",
+		"devices/1-1/product":         "USB Optical Mouse\n",
+		"devices/1-1/speed":           "1.5\n",
+		"devices/1-1/version":         " 2.00\n",
+		"devices/1-1:1.0/bInterfaces": "1\n",
+		"devices/1-2/idVendor":        "0000\n",
+		"devices/1-2/idProduct":       "0000\n",
+		"devices/1-2/bDeviceClass":    "00\n",
+		"devices/1-2/busnum":          "1\n",
+		"devices/1-2/devnum":          "5\n",
+		"devices/1-2/speed":           "unknown\n",
+		"devices/1-2/version":         " 0.00\n",
+		"drivers/usb/bind":            "",
+		"usb.ids":                     usbIdsFixture,
+	})
+
+	tErrorIf(t, os.Symlink(filepath.Join(root, "drivers", "usb"), filepath.Join(root, "devices", "1-1", "driver")))
+
+	return root
+}
+
+func TestUSBDevices(t *testing.T) {
+	var (
+		root     string
+		db       *IdsDatabase
+		usbInfos []*USBDeviceInfo
+		driver   string
+		ok       bool
+		err      error
+	)
+
+	root = usbTree(t)
+
+	db, err = LoadIds(filepath.Join(root, "usb.ids"))
+	tErrorIf(t, err)
+
+	usbInfos, err = usbDevices(filepath.Join(root, "devices"), "*", db)
+	tErrorIf(t, err)
+
+	if len(usbInfos) != 3 {
+		t.Fatalf("expected 3 devices, got %d", len(usbInfos))
+	}
+
+	driver, ok = usbInfos[0].Driver()
+	if usbInfos[0].Name() != "1-1" || !ok || driver != "usb" || usbInfos[0].Speed() != 1.5 || usbInfos[0].Manufacturer() != "" || usbInfos[0].Product() != "USB Optical Mouse" {
+		t.Errorf("unexpected mouse %+v", usbInfos[0])
+	}
+
+	if usbInfos[0].VendorName() != "Logitech, Inc." || usbInfos[0].ProductName() != "M105 Optical Mouse" || usbInfos[0].DevicePath() != "/dev/bus/usb/001/004" {
+		t.Errorf("unexpected mouse names %+v", usbInfos[0])
+	}
+
+	if usbInfos[1].Name() != "1-2" || usbInfos[1].Speed() != 0 {
+		t.Errorf("expected %q of unknown speed, got %+v", "1-2", usbInfos[1])
+	}
+
+	if usbInfos[2].Class() != 0x09 || usbInfos[2].Version() != "2.00" || usbInfos[2].VendorId() != 0x1d6b || usbInfos[2].ProductName() != "2.0 root hub" || usbInfos[2].Serial() != "0000:00:14.0" {
+		t.Errorf("unexpected root hub %+v", usbInfos[2])
+	}
+
+	if !checkPath(t, USBPath) {
+		return
+	}
+
+	_, err = USBDevices("*", "")
+	tErrorIf(t, err)
+}
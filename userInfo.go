@@ -1,17 +1,46 @@
 package sstat
 
 import (
+	"bufio"
+	"errors"
+	"io/fs"
 	"os"
 	"os/user"
+	"strings"
 )
 
+// PasswdPath is the path to the file where user accounts are stored.
+const PasswdPath string = "/etc/passwd"
+
+// GroupInfo contains group information.
+type GroupInfo struct {
+	gid  string
+	name string
+}
+
+// Gid reports the group ID.
+func (info *GroupInfo) Gid() string {
+	return info.gid
+}
+
+// Name reports the group name. It is blank if the
+// group ID has no associated group.
+func (info *GroupInfo) Name() string {
+	return info.name
+}
+
 // UserInfo contains user information.
 type UserInfo struct {
-	uid      string
-	gid      string
-	username string
-	group    string
-	hostname string
+	uid         string
+	gid         string
+	username    string
+	name        string
+	group       string
+	groups      []*GroupInfo
+	homeDir     string
+	shell       string
+	hostname    string
+	credentials *CredentialsInfo
 }
 
 // Uid reports the user ID.
@@ -35,20 +64,91 @@ func (info *UserInfo) Username() string {
 	return info.username
 }
 
+// Name reports the real or display name of the user taken
+// from the GECOS field. It might be blank.
+func (info *UserInfo) Name() string {
+	return info.name
+}
+
 // Group reports the primary group name.
 func (info *UserInfo) Group() string {
 	return info.group
 }
 
+// Groups reports every group the user is a member of,
+// including the primary group. It is empty if the groups
+// cannot be enumerated, such as for some LDAP users.
+func (info *UserInfo) Groups() []*GroupInfo {
+	return info.groups
+}
+
+// InGroup reports whether the user is a member of the group name.
+func (info *UserInfo) InGroup(name string) bool {
+	var idx int
+
+	for idx = range info.groups {
+		if info.groups[idx].name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HomeDir reports the path to the home directory of the user.
+func (info *UserInfo) HomeDir() string {
+	return info.homeDir
+}
+
+// Shell reports the login shell of the user as found in [PasswdPath].
+// It is blank for users that are not found in [PasswdPath], such as
+// users provided by a network directory.
+func (info *UserInfo) Shell() string {
+	return info.shell
+}
+
 // Hostname reports the host name reported by the kernel.
 func (info *UserInfo) Hostname() string {
 	return info.hostname
 }
 
+// Credentials reports the credentials of the current process and
+// whether if the user information was returned by [CurrentUser] or not.
+func (info *UserInfo) Credentials() (value *CredentialsInfo, ok bool) {
+	return info.credentials, info.credentials != nil
+}
+
+func passwdShell(path, username string) (string, error) {
+	var (
+		shell string
+		err   error
+	)
+
+	err = ScanFile(path, bufio.ScanLines, func(text string) (bool, error) {
+		var fields []string
+
+		fields = strings.Split(text, ":")
+		if len(fields) != 7 || fields[0] != username {
+			return true, nil
+		}
+
+		shell = fields[6]
+
+		return false, nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	return shell, err
+}
+
 func mkUserInfo(account *user.User) (*UserInfo, error) {
 	var (
 		userInfo *UserInfo
 		group    *user.Group
+		gids     []string
+		idx      int
 		err      error
 	)
 
@@ -61,7 +161,31 @@ func mkUserInfo(account *user.User) (*UserInfo, error) {
 		uid:      account.Uid,
 		gid:      account.Gid,
 		username: account.Username,
+		name:     account.Name,
 		group:    group.Name,
+		homeDir:  account.HomeDir,
+	}
+
+	// Group enumeration is not supported by every NSS
+	// backend, such as LDAP, which leaves groups empty.
+	gids, _ = account.GroupIds()
+
+	userInfo.groups = make([]*GroupInfo, len(gids))
+
+	for idx = range gids {
+		userInfo.groups[idx] = &GroupInfo{gid: gids[idx]}
+
+		group, err = user.LookupGroupId(gids[idx])
+		if err != nil {
+			continue
+		}
+
+		userInfo.groups[idx].name = group.Name
+	}
+
+	userInfo.shell, err = passwdShell(PasswdPath, account.Username)
+	if err != nil {
+		return nil, err
 	}
 
 	userInfo.hostname, err = os.Hostname()
@@ -104,11 +228,13 @@ func LookupUserId(uid string) (*UserInfo, error) {
 	return mkUserInfo(account)
 }
 
-// CurrentUser returns information about the current user.
+// CurrentUser returns information about the current user,
+// including the credentials of the current process.
 func CurrentUser() (*UserInfo, error) {
 	var (
-		account *user.User
-		err     error
+		userInfo *UserInfo
+		account  *user.User
+		err      error
 	)
 
 	account, err = user.Current()
@@ -116,5 +242,15 @@ func CurrentUser() (*UserInfo, error) {
 		return nil, err
 	}
 
-	return mkUserInfo(account)
+	userInfo, err = mkUserInfo(account)
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo.credentials, err = SelfCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	return userInfo, nil
 }
@@ -50,3 +50,20 @@ func ExampleLookupUserId() {
 
 	fmt.Println(userInfo.Group())
 }
+
+// Warn if the current user can gain root through docker.
+func ExampleUserInfo_InGroup() {
+	var (
+		userInfo *sstat.UserInfo
+		err      error
+	)
+
+	userInfo, err = sstat.CurrentUser()
+	if err != nil {
+		panic(err)
+	}
+
+	if userInfo.InGroup("docker") {
+		fmt.Printf("%s is in the docker group\n", userInfo.Username())
+	}
+}
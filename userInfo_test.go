@@ -1,6 +1,9 @@
 package sstat
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+)
 
 func TestCurrentUser(t *testing.T) {
 	var err error
@@ -22,3 +25,28 @@ func TestLookupUserId(t *testing.T) {
 	_, err = LookupUserId("0")
 	tErrorIf(t, err)
 }
+
+func TestPasswdShell(t *testing.T) {
+	var (
+		root, shell string
+		err         error
+	)
+
+	root = tmpTree(t, map[string]string{
+		"passwd": "root:x:0:0::/root:/bin/bash\nalice:x:1000:1000:Alice,,,:/home/alice:/usr/bin/zsh\n",
+	})
+
+	shell, err = passwdShell(filepath.Join(root, "passwd"), "alice")
+	tErrorIf(t, err)
+
+	if shell != "/usr/bin/zsh" {
+		t.Errorf("expected %q, got %q", "/usr/bin/zsh", shell)
+	}
+
+	shell, err = passwdShell(filepath.Join(root, "passwd"), "bob")
+	tErrorIf(t, err)
+
+	if shell != "" {
+		t.Errorf("expected %q, got %q", "", shell)
+	}
+}
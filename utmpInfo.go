@@ -0,0 +1,329 @@
+package sstat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// UtmpPath is the path to the file where the
+// currently logged in sessions are recorded.
+const UtmpPath string = "/var/run/utmp"
+
+// WtmpPath is the path to the file where every
+// login and logout is recorded.
+const WtmpPath string = "/var/log/wtmp"
+
+// Valid values of [UtmpEntry.Type] as defined in utmp.h.
+const (
+	UtmpEmpty = iota
+	UtmpRunLvl
+	UtmpBootTime
+	UtmpNewTime
+	UtmpOldTime
+	UtmpInitProcess
+	UtmpLoginProcess
+	UtmpUserProcess
+	UtmpDeadProcess
+	UtmpAccounting
+)
+
+// utmpRecord is the glibc struct utmp layout used
+// on 64-bit and 32-bit linux systems alike.
+type utmpRecord struct {
+	Type        int16
+	_           [2]byte
+	Pid         int32
+	Line        [32]byte
+	Id          [4]byte
+	User        [32]byte
+	Host        [256]byte
+	Termination int16
+	Exit        int16
+	Session     int32
+	Sec         int32
+	Usec        int32
+	Addr        [16]byte
+	_           [20]byte
+}
+
+// UtmpEntry reports a single utmp or wtmp record.
+// Documentation for the object methods are taken from [utmp(5)].
+//
+// [utmp(5)]: https://man.archlinux.org/man/utmp.5.en
+type UtmpEntry struct {
+	typ         int
+	pid         int
+	line        string
+	id          string
+	user        string
+	host        string
+	termination int
+	exit        int
+	session     int
+	time        time.Time
+	addr        net.IP
+}
+
+// Type reports the type of record.
+//
+// Valid values are:
+//   - [UtmpEmpty]        : Record does not contain valid info
+//   - [UtmpRunLvl]       : Change in system run-level
+//   - [UtmpBootTime]     : Time of system boot
+//   - [UtmpNewTime]      : Time after system clock change
+//   - [UtmpOldTime]      : Time before system clock change
+//   - [UtmpInitProcess]  : Process spawned by init
+//   - [UtmpLoginProcess] : Session leader process for user login
+//   - [UtmpUserProcess]  : Normal process
+//   - [UtmpDeadProcess]  : Terminated process
+//   - [UtmpAccounting]   : Not implemented
+func (entry *UtmpEntry) Type() (value int) {
+	return entry.typ
+}
+
+// Pid reports the PID of the login process.
+func (entry *UtmpEntry) Pid() (value int) {
+	return entry.pid
+}
+
+// Line reports the device name of the tty without the "/dev/" prefix.
+func (entry *UtmpEntry) Line() (value string) {
+	return entry.line
+}
+
+// Id reports the terminal name suffix, or inittab(5) ID.
+func (entry *UtmpEntry) Id() (value string) {
+	return entry.id
+}
+
+// User reports the username.
+func (entry *UtmpEntry) User() (value string) {
+	return entry.user
+}
+
+// Host reports the hostname for remote login, or kernel
+// version for run-level messages. Local graphical sessions
+// usually report the X11 display, e.g. ":0".
+func (entry *UtmpEntry) Host() (value string) {
+	return entry.host
+}
+
+// Termination reports the process termination status
+// of a [UtmpDeadProcess].
+func (entry *UtmpEntry) Termination() (value int) {
+	return entry.termination
+}
+
+// Exit reports the process exit status of a [UtmpDeadProcess].
+func (entry *UtmpEntry) Exit() (value int) {
+	return entry.exit
+}
+
+// Session reports the session ID used for windowing.
+func (entry *UtmpEntry) Session() (value int) {
+	return entry.session
+}
+
+// Time reports the time the entry was made.
+func (entry *UtmpEntry) Time() (value time.Time) {
+	return entry.time
+}
+
+// Addr reports the internet address of the remote host.
+// The value is nil for local sessions.
+func (entry *UtmpEntry) Addr() (value net.IP) {
+	return entry.addr
+}
+
+func utmpStr(buf []byte) string {
+	var idx int
+
+	idx = bytes.IndexByte(buf, 0)
+	if idx == -1 {
+		return string(buf)
+	}
+
+	return string(buf[:idx])
+}
+
+func utmpAddr(buf [16]byte) net.IP {
+	if buf == [16]byte{} {
+		return nil
+	}
+
+	if [12]byte(buf[4:]) == [12]byte{} {
+		return net.IPv4(buf[0], buf[1], buf[2], buf[3])
+	}
+
+	return net.IP(bytes.Clone(buf[:]))
+}
+
+// ParseUtmp decodes every utmp record in r.
+// The records are expected to be in the glibc utmp layout
+// in native byte order.
+func ParseUtmp(r io.Reader) ([]*UtmpEntry, error) {
+	var (
+		entries []*UtmpEntry
+		record  utmpRecord
+		err     error
+	)
+
+	for {
+		err = binary.Read(r, binary.NativeEndian, &record)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.New("truncated utmp record")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &UtmpEntry{
+			typ:         int(record.Type),
+			pid:         int(record.Pid),
+			line:        utmpStr(record.Line[:]),
+			id:          utmpStr(record.Id[:]),
+			user:        utmpStr(record.User[:]),
+			host:        utmpStr(record.Host[:]),
+			termination: int(record.Termination),
+			exit:        int(record.Exit),
+			session:     int(record.Session),
+			time:        time.Unix(int64(record.Sec), int64(record.Usec)*int64(time.Microsecond)),
+			addr:        utmpAddr(record.Addr),
+		})
+	}
+}
+
+// Utmp returns every record in the utmp formatted file in path,
+// such as [UtmpPath] or [WtmpPath].
+func Utmp(path string) ([]*UtmpEntry, error) {
+	var (
+		file    *os.File
+		entries []*UtmpEntry
+		err     error
+	)
+
+	file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err = ParseUtmp(file)
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return entries, file.Close()
+}
+
+func sessions(path string) ([]*UtmpEntry, error) {
+	var (
+		entries, sessionEntries []*UtmpEntry
+		idx                     int
+		err                     error
+	)
+
+	entries, err = Utmp(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx = range entries {
+		if entries[idx].typ == UtmpUserProcess {
+			sessionEntries = append(sessionEntries, entries[idx])
+		}
+	}
+
+	return sessionEntries, nil
+}
+
+// Sessions returns the sessions of the currently
+// logged in users from [UtmpPath].
+func Sessions() ([]*UtmpEntry, error) {
+	return sessions(UtmpPath)
+}
+
+// LoginInfo reports a single login session from the login history.
+type LoginInfo struct {
+	UtmpEntry
+	logout time.Time
+	active bool
+}
+
+// Login reports the time the user logged in.
+func (info *LoginInfo) Login() (value time.Time) {
+	return info.time
+}
+
+// Logout reports the time the session ended
+// and whether if the session has ended or not.
+// Sessions interrupted by a reboot end at the time of the boot.
+func (info *LoginInfo) Logout() (value time.Time, ok bool) {
+	return info.logout, !info.active
+}
+
+func loginHistory(path string) ([]*LoginInfo, error) {
+	var (
+		entries    []*UtmpEntry
+		loginInfos []*LoginInfo
+		open       map[string]*LoginInfo
+		loginInfo  *LoginInfo
+		line       string
+		idx        int
+		err        error
+	)
+
+	entries, err = Utmp(path)
+	if err != nil {
+		return nil, err
+	}
+
+	open = make(map[string]*LoginInfo)
+
+	for idx = range entries {
+		switch entries[idx].typ {
+		case UtmpUserProcess:
+			loginInfo = &LoginInfo{
+				UtmpEntry: *entries[idx],
+				active:    true,
+			}
+
+			loginInfos = append(loginInfos, loginInfo)
+			open[entries[idx].line] = loginInfo
+		case UtmpDeadProcess:
+			loginInfo = open[entries[idx].line]
+			if loginInfo == nil {
+				continue
+			}
+
+			loginInfo.logout = entries[idx].time
+			loginInfo.active = false
+			delete(open, entries[idx].line)
+		case UtmpBootTime:
+			for line, loginInfo = range open {
+				loginInfo.logout = entries[idx].time
+				loginInfo.active = false
+				delete(open, line)
+			}
+		}
+	}
+
+	return loginInfos, nil
+}
+
+// LoginHistory returns every login session recorded in [WtmpPath],
+// oldest first, pairing each login with its logout.
+func LoginHistory() ([]*LoginInfo, error) {
+	return loginHistory(WtmpPath)
+}
@@ -0,0 +1,45 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print who is logged in, where and since when.
+func ExampleSessions() {
+	var (
+		entries []*sstat.UtmpEntry
+		idx     int
+		err     error
+	)
+
+	entries, err = sstat.Sessions()
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range entries {
+		fmt.Printf("%s %s %s %s\n", entries[idx].User(), entries[idx].Line(), entries[idx].Host(), entries[idx].Time())
+	}
+}
+
+// Print the last login of every session.
+func ExampleLoginHistory() {
+	var (
+		loginInfos []*sstat.LoginInfo
+		idx        int
+		ok         bool
+		err        error
+	)
+
+	loginInfos, err = sstat.LoginHistory()
+	if err != nil {
+		panic(err)
+	}
+
+	for idx = range loginInfos {
+		_, ok = loginInfos[idx].Logout()
+		fmt.Printf("%s %s %s still logged in: %t\n", loginInfos[idx].User(), loginInfos[idx].Line(), loginInfos[idx].Login(), !ok)
+	}
+}
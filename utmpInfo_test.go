@@ -0,0 +1,98 @@
+package sstat
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseUtmp(t *testing.T) {
+	var (
+		entries []*UtmpEntry
+		err     error
+	)
+
+	entries, err = Utmp(filepath.Join("testdata", "utmp"))
+	tErrorIf(t, err)
+
+	if len(entries) != 5 {
+		t.Fatalf("expected %d records, got %d", 5, len(entries))
+	}
+
+	if entries[0].Type() != UtmpBootTime || entries[0].Host() != "6.1.0-arch1" {
+		t.Errorf("unexpected boot record %+v", *entries[0])
+	}
+
+	if entries[4].User() != "bob" || entries[4].Line() != "pts/0" || entries[4].Addr().String() != "203.0.113.7" {
+		t.Errorf("unexpected remote session record %+v", *entries[4])
+	}
+
+	if !entries[4].Time().Equal(time.Unix(1700000200, 0)) {
+		t.Errorf("expected %v, got %v", time.Unix(1700000200, 0), entries[4].Time())
+	}
+
+	_, err = ParseUtmp(bytes.NewReader(make([]byte, 100)))
+	if err == nil {
+		t.Error("expected error for truncated record")
+	}
+}
+
+func TestSessions(t *testing.T) {
+	var (
+		entries []*UtmpEntry
+		err     error
+	)
+
+	entries, err = sessions(filepath.Join("testdata", "utmp"))
+	tErrorIf(t, err)
+
+	if len(entries) != 2 || entries[0].User() != "alice" || entries[0].Host() != ":0" || entries[0].Addr() != nil {
+		t.Errorf("unexpected sessions %v", entries)
+	}
+
+	if !checkPath(t, UtmpPath) {
+		return
+	}
+
+	_, err = Sessions()
+	tErrorIf(t, err)
+}
+
+func TestLoginHistory(t *testing.T) {
+	var (
+		loginInfos []*LoginInfo
+		logout     time.Time
+		ok         bool
+		err        error
+	)
+
+	loginInfos, err = loginHistory(filepath.Join("testdata", "wtmp"))
+	tErrorIf(t, err)
+
+	if len(loginInfos) != 3 {
+		t.Fatalf("expected %d logins, got %d", 3, len(loginInfos))
+	}
+
+	logout, ok = loginInfos[0].Logout()
+	if !ok || !logout.Equal(time.Unix(1700090000, 0)) {
+		t.Errorf("expected session of %s to end at reboot, got %v", loginInfos[0].User(), logout)
+	}
+
+	logout, ok = loginInfos[1].Logout()
+	if !ok || !logout.Equal(time.Unix(1700000500, 0)) || loginInfos[1].Addr().String() != "2001:db8::1" {
+		t.Errorf("unexpected session of %s ending at %v", loginInfos[1].User(), logout)
+	}
+
+	_, ok = loginInfos[2].Logout()
+	if ok {
+		t.Errorf("expected session of %s to be active", loginInfos[2].User())
+	}
+
+	if !checkPath(t, WtmpPath) {
+		return
+	}
+
+	_, err = LoginHistory()
+	tErrorIf(t, err)
+}
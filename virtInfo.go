@@ -0,0 +1,341 @@
+package sstat
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VirtInfo reports whether the system runs inside a virtual machine
+// and/or a container. Detection is heuristic and only inspects files,
+// similar to systemd-detect-virt(1) without CPUID.
+type VirtInfo struct {
+	hypervisor string
+	container  string
+}
+
+// Hypervisor reports the hypervisor the system runs on.
+// It is blank on bare metal.
+//
+// Valid values are:
+//   - "kvm"
+//   - "qemu"
+//   - "xen"
+//   - "vmware"
+//   - "microsoft" : Hyper-V
+//   - "oracle"    : VirtualBox
+//   - "unknown"   : /proc/cpuinfo reports a hypervisor but the vendor is unknown
+func (info *VirtInfo) Hypervisor() (value string) {
+	return info.hypervisor
+}
+
+// Container reports the container runtime the system runs in.
+// It is blank outside of containers.
+//
+// Valid values are:
+//   - "docker"
+//   - "podman"
+//   - "lxc"
+//   - "systemd-nspawn"
+//   - "wsl"
+//
+// Other values may be reported by the container manager through
+// /run/systemd/container or the container environment variable of PID 1.
+// An overlay root filesystem alone, as booted by live ISOs, is not taken
+// as a container.
+func (info *VirtInfo) Container() (value string) {
+	return info.container
+}
+
+// IsVM reports whether the system runs inside a virtual machine.
+func (info *VirtInfo) IsVM() bool {
+	return info.hypervisor != ""
+}
+
+// IsContainer reports whether the system runs inside a container.
+func (info *VirtInfo) IsContainer() bool {
+	return info.container != ""
+}
+
+func pathExists(path string) (bool, error) {
+	var err error
+
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func scanOptionalFile(path string, parser func(text string) (ok bool, err error)) error {
+	var err error
+
+	err = ScanFile(path, bufio.ScanLines, parser)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return nil
+	}
+
+	return err
+}
+
+func dmiHypervisor(root string) (string, error) {
+	var (
+		dmiInfo map[string]string
+		key     string
+		err     error
+	)
+
+	dmiInfo, err = dmi(filepath.Join(root, DMIPath))
+	if err != nil {
+		return "", err
+	}
+
+	for _, key = range []string{"product_name", "sys_vendor", "board_vendor", "bios_vendor"} {
+		switch {
+		case strings.HasPrefix(dmiInfo[key], "KVM"):
+			return "kvm", nil
+		case strings.HasPrefix(dmiInfo[key], "QEMU"):
+			return "qemu", nil
+		case strings.HasPrefix(dmiInfo[key], "VMware"), strings.HasPrefix(dmiInfo[key], "VMW"):
+			return "vmware", nil
+		case strings.HasPrefix(dmiInfo[key], "innotek GmbH"), strings.HasPrefix(dmiInfo[key], "VirtualBox"), strings.HasPrefix(dmiInfo[key], "Oracle Corporation"):
+			return "oracle", nil
+		case strings.HasPrefix(dmiInfo[key], "Xen"):
+			return "xen", nil
+		case strings.HasPrefix(dmiInfo[key], "Microsoft Corporation") && dmiInfo["product_name"] == "Virtual Machine":
+			return "microsoft", nil
+		}
+	}
+
+	return "", nil
+}
+
+func cpuinfoHypervisor(root string) (bool, error) {
+	var (
+		found bool
+		err   error
+	)
+
+	err = scanOptionalFile(filepath.Join(root, ProcPath, "cpuinfo"), func(text string) (bool, error) {
+		var (
+			key, value string
+			ok         bool
+		)
+
+		key, value, ok = strings.Cut(text, ":")
+		if !ok || strings.TrimSpace(key) != "flags" {
+			return true, nil
+		}
+
+		found = strings.Contains(" "+value+" ", " hypervisor ")
+
+		return false, nil
+	})
+
+	return found, err
+}
+
+func detectHypervisor(root string) (string, error) {
+	var (
+		hypervisor string
+		found      bool
+		err        error
+	)
+
+	hypervisor, err = pathReadOptionalStr(filepath.Join(root, "sys", "hypervisor", "type"))
+	if err != nil {
+		return "", err
+	}
+
+	if hypervisor == "xen" {
+		return "xen", nil
+	}
+
+	hypervisor, err = dmiHypervisor(root)
+	if err != nil || hypervisor != "" {
+		return hypervisor, err
+	}
+
+	found, err = cpuinfoHypervisor(root)
+	if err != nil || !found {
+		return "", err
+	}
+
+	return "unknown", nil
+}
+
+func cgroupContainer(root string) (string, error) {
+	var (
+		container string
+		err       error
+	)
+
+	err = scanOptionalFile(filepath.Join(root, ProcPath, "1", "cgroup"), func(text string) (bool, error) {
+		switch {
+		case strings.Contains(text, "/docker/"), strings.Contains(text, "/docker-"):
+			container = "docker"
+		case strings.Contains(text, "/libpod"):
+			container = "podman"
+		case strings.Contains(text, "/lxc/"), strings.Contains(text, "/lxc.payload"):
+			container = "lxc"
+		case strings.Contains(text, "/machine.slice/machine-"):
+			container = "systemd-nspawn"
+		}
+
+		return container == "", nil
+	})
+
+	return container, err
+}
+
+// environContainer reports the container environment variable
+// of PID 1, which is set by most container managers.
+func environContainer(root string) (string, error) {
+	var (
+		environ, env string
+		value        string
+		found        bool
+		err          error
+	)
+
+	environ, err = pathReadOptionalStr(filepath.Join(root, ProcPath, "1", "environ"))
+	if err != nil {
+		return "", err
+	}
+
+	for _, env = range strings.Split(environ, "\x00") {
+		value, found = strings.CutPrefix(env, "container=")
+		if found {
+			return value, nil
+		}
+	}
+
+	return "", nil
+}
+
+func mountinfoContainer(root string) (string, error) {
+	var (
+		container string
+		err       error
+	)
+
+	err = scanOptionalFile(filepath.Join(root, ProcPath, "self", "mountinfo"), func(text string) (bool, error) {
+		var (
+			fields   []string
+			fsFields string
+			found    bool
+		)
+
+		fields = strings.Fields(text)
+		if len(fields) < 5 || fields[4] != "/" {
+			return true, nil
+		}
+
+		_, fsFields, found = strings.Cut(text, " - ")
+		if !found {
+			return false, errors.New("invalid mountinfo format")
+		}
+
+		fields = strings.Fields(fsFields)
+		if len(fields) == 0 || fields[0] != "overlay" {
+			return true, nil
+		}
+
+		switch {
+		case strings.Contains(fsFields, "/docker/"):
+			container = "docker"
+		case strings.Contains(fsFields, "/containers/storage/"):
+			container = "podman"
+		}
+
+		return true, nil
+	})
+
+	return container, err
+}
+
+func detectContainer(root string) (string, error) {
+	var (
+		container, osrelease string
+		found                bool
+		err                  error
+	)
+
+	found, err = pathExists(filepath.Join(root, ".dockerenv"))
+	if err != nil {
+		return "", err
+	}
+
+	if found {
+		return "docker", nil
+	}
+
+	found, err = pathExists(filepath.Join(root, "run", ".containerenv"))
+	if err != nil {
+		return "", err
+	}
+
+	if found {
+		return "podman", nil
+	}
+
+	container, err = pathReadOptionalStr(filepath.Join(root, "run", "systemd", "container"))
+	if err != nil || container != "" {
+		return container, err
+	}
+
+	osrelease, err = pathReadOptionalStr(filepath.Join(root, ProcPath, "sys", "kernel", "osrelease"))
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(strings.ToLower(osrelease), "microsoft") {
+		return "wsl", nil
+	}
+
+	container, err = environContainer(root)
+	if err != nil || container != "" {
+		return container, err
+	}
+
+	container, err = cgroupContainer(root)
+	if err != nil || container != "" {
+		return container, err
+	}
+
+	return mountinfoContainer(root)
+}
+
+func virt(root string) (*VirtInfo, error) {
+	var (
+		virtInfo *VirtInfo
+		err      error
+	)
+
+	virtInfo = new(VirtInfo)
+
+	virtInfo.hypervisor, err = detectHypervisor(root)
+	if err != nil {
+		return nil, err
+	}
+
+	virtInfo.container, err = detectContainer(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return virtInfo, nil
+}
+
+// Virt returns the detected hypervisor and container runtime.
+// It inspects [DMIPath], /proc/cpuinfo, /sys/hypervisor, /proc/1/cgroup,
+// /proc/1/environ, /.dockerenv, /run/.containerenv,
+// /run/systemd/container, /proc/sys/kernel/osrelease and
+// /proc/self/mountinfo.
+func Virt() (*VirtInfo, error) {
+	return virt("/")
+}
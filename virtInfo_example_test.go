@@ -0,0 +1,28 @@
+package sstat_test
+
+import (
+	"fmt"
+
+	"github.com/andrieee44/sstat"
+)
+
+// Print the hypervisor and container runtime of the system.
+func ExampleVirt() {
+	var (
+		virtInfo *sstat.VirtInfo
+		err      error
+	)
+
+	virtInfo, err = sstat.Virt()
+	if err != nil {
+		panic(err)
+	}
+
+	if virtInfo.IsVM() {
+		fmt.Println("hypervisor:", virtInfo.Hypervisor())
+	}
+
+	if virtInfo.IsContainer() {
+		fmt.Println("container:", virtInfo.Container())
+	}
+}
@@ -0,0 +1,90 @@
+package sstat
+
+import "testing"
+
+func TestVirt(t *testing.T) {
+	var (
+		tests = []struct {
+			name                  string
+			files                 map[string]string
+			hypervisor, container string
+		}{
+			{"bare metal", map[string]string{
+				"proc/cpuinfo":                "flags\t\t: fpu vme de pse\n",
+				"sys/class/dmi/id/sys_vendor": "LENOVO\n",
+				"proc/1/cgroup":               "0::/init.scope\n",
+				"proc/self/mountinfo":         "28 1 259:2 / / rw,relatime shared:1 - ext4 /dev/nvme0n1p2 rw\n",
+			}, "", ""},
+			{"kvm", map[string]string{
+				"proc/cpuinfo":                  "flags\t\t: fpu vme hypervisor lahf_lm\n",
+				"sys/class/dmi/id/sys_vendor":   "QEMU\n",
+				"sys/class/dmi/id/product_name": "KVM\n",
+			}, "kvm", ""},
+			{"qemu", map[string]string{
+				"sys/class/dmi/id/sys_vendor":   "QEMU\n",
+				"sys/class/dmi/id/product_name": "Standard PC (Q35 + ICH9, 2009)\n",
+			}, "qemu", ""},
+			{"xen", map[string]string{
+				"sys/hypervisor/type": "xen\n",
+			}, "xen", ""},
+			{"vmware", map[string]string{
+				"sys/class/dmi/id/sys_vendor":   "VMware, Inc.\n",
+				"sys/class/dmi/id/product_name": "VMware7,1\n",
+			}, "vmware", ""},
+			{"hyper-v", map[string]string{
+				"sys/class/dmi/id/sys_vendor":   "Microsoft Corporation\n",
+				"sys/class/dmi/id/product_name": "Virtual Machine\n",
+			}, "microsoft", ""},
+			{"unknown hypervisor", map[string]string{
+				"proc/cpuinfo": "processor\t: 0\nflags\t\t: fpu hypervisor\n",
+			}, "unknown", ""},
+			{"docker", map[string]string{
+				".dockerenv": "",
+			}, "", "docker"},
+			{"docker cgroup", map[string]string{
+				"proc/1/cgroup": "12:pids:/docker/3f4e5d\n0::/docker/3f4e5d\n",
+			}, "", "docker"},
+			{"podman", map[string]string{
+				"run/.containerenv": "engine=\"podman-4.9.3\"\n",
+			}, "", "podman"},
+			{"lxc", map[string]string{
+				"proc/1/cgroup": "0::/lxc.payload.web01/init.scope\n",
+			}, "", "lxc"},
+			{"systemd-nspawn", map[string]string{
+				"run/systemd/container": "systemd-nspawn\n",
+			}, "", "systemd-nspawn"},
+			{"empty systemd container", map[string]string{
+				"run/systemd/container":     "",
+				"proc/sys/kernel/osrelease": "",
+			}, "", ""},
+			{"wsl", map[string]string{
+				"proc/sys/kernel/osrelease": "5.15.153.1-microsoft-standard-WSL2\n",
+			}, "", "wsl"},
+			{"overlay root", map[string]string{
+				"proc/self/mountinfo": "600 550 0:52 / / rw,relatime - overlay overlay rw,lowerdir=/var/lib/containers/storage/overlay/l/ABC\n",
+			}, "", "podman"},
+			{"live ISO overlay root", map[string]string{
+				"proc/self/mountinfo": "600 550 0:52 / / rw,relatime - overlay overlay rw,lowerdir=/run/rootfsbase,upperdir=/run/overlayfs\n",
+			}, "", ""},
+			{"container environment", map[string]string{
+				"proc/1/environ":      "PATH=/usr/bin\x00container=oci\x00HOME=/\x00",
+				"proc/self/mountinfo": "600 550 0:52 / / rw,relatime - overlay overlay rw,lowerdir=/lower,upperdir=/upper\n",
+			}, "", "oci"},
+		}
+		virtInfo *VirtInfo
+		idx      int
+		err      error
+	)
+
+	for idx = range tests {
+		virtInfo, err = virt(tmpTree(t, tests[idx].files))
+		tErrorIf(t, err)
+
+		if virtInfo.Hypervisor() != tests[idx].hypervisor || virtInfo.Container() != tests[idx].container {
+			t.Errorf("%s: expected %q %q, got %q %q", tests[idx].name, tests[idx].hypervisor, tests[idx].container, virtInfo.Hypervisor(), virtInfo.Container())
+		}
+	}
+
+	_, err = Virt()
+	tErrorIf(t, err)
+}